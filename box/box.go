@@ -0,0 +1,169 @@
+// Package box implements axis-aligned boxes (rectangles, cuboids and their
+// higher-dimensional equivalents) over integer coordinates.
+package box
+
+import (
+	"iter"
+
+	"github.com/glennhartmann/aoclib/common"
+	"github.com/glennhartmann/aoclib/grid/d8"
+	"github.com/glennhartmann/aoclib/interval"
+)
+
+// Box is an axis-aligned box in len(Axes) dimensions: the set of points whose
+// i'th coordinate is in Axes[i], for every i. Bounds are inclusive, as with
+// interval.Interval.
+type Box[T common.Integer] struct {
+	Axes []interval.Interval[T]
+}
+
+// New returns the Box with the given |axes|.
+func New[T common.Integer](axes ...interval.Interval[T]) Box[T] {
+	return Box[T]{axes}
+}
+
+// FromCorners returns the Box with opposite corners |lo| and |hi|, which must
+// have the same number of dimensions.
+func FromCorners[T common.Integer](lo, hi []T) Box[T] {
+	if len(lo) != len(hi) {
+		common.Panicf("FromCorners: corners have %d and %d dimensions", len(lo), len(hi))
+	}
+	axes := make([]interval.Interval[T], len(lo))
+	for i := range lo {
+		axes[i] = interval.New(lo[i], hi[i])
+	}
+	return Box[T]{axes}
+}
+
+// FromPoints returns the 2-D Box with opposite corners |a| and |b|. Axis 0 is
+// rows and axis 1 is columns.
+func FromPoints(a, b d8.Point) Box[int] {
+	return FromCorners([]int{a.R, a.C}, []int{b.R, b.C})
+}
+
+// Dims returns the number of dimensions of the Box.
+func (b Box[T]) Dims() int { return len(b.Axes) }
+
+// Empty returns whether or not the Box contains no points.
+func (b Box[T]) Empty() bool {
+	for _, a := range b.Axes {
+		if a.Empty() {
+			return true
+		}
+	}
+	return false
+}
+
+// Volume returns the number of points in the Box.
+func (b Box[T]) Volume() T {
+	var ret T = 1
+	for _, a := range b.Axes {
+		ret *= a.Len()
+	}
+	return ret
+}
+
+// Contains returns whether or not the point |p| is in the Box. It panics if
+// |p| has the wrong number of dimensions.
+func (b Box[T]) Contains(p ...T) bool {
+	if len(p) != b.Dims() {
+		common.Panicf("Contains: %d-D point in %d-D box", len(p), b.Dims())
+	}
+	for i, a := range b.Axes {
+		if !a.Contains(p[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsPoint returns whether or not the 2-D Box contains |p|.
+func (b Box[T]) ContainsPoint(p d8.Point) bool {
+	return b.Contains(T(p.R), T(p.C))
+}
+
+// ContainsBox returns whether or not every point in |o| is also in the Box.
+// An empty Box is contained by everything.
+func (b Box[T]) ContainsBox(o Box[T]) bool {
+	b.mustMatch(o)
+	if o.Empty() {
+		return true
+	}
+	for i, a := range b.Axes {
+		if !a.ContainsInterval(o.Axes[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Intersect returns the points in both the Box and |o|, and whether or not
+// there are any.
+func (b Box[T]) Intersect(o Box[T]) (Box[T], bool) {
+	b.mustMatch(o)
+	axes := make([]interval.Interval[T], len(b.Axes))
+	for i, a := range b.Axes {
+		var ok bool
+		if axes[i], ok = a.Intersect(o.Axes[i]); !ok {
+			return Box[T]{axes}, false
+		}
+	}
+	return Box[T]{axes}, true
+}
+
+// Subtract returns the points in the Box but not in |o|, as at most 2*Dims()
+// disjoint, non-empty Boxes.
+func (b Box[T]) Subtract(o Box[T]) []Box[T] {
+	b.mustMatch(o)
+	if b.Empty() {
+		return nil
+	}
+	if _, ok := b.Intersect(o); !ok {
+		return []Box[T]{b}
+	}
+
+	// Peel off the parts below and above |o| one axis at a time, shrinking the
+	// remainder to o's extent on that axis as we go.
+	var ret []Box[T]
+	rest := b.clone()
+	for i, a := range rest.Axes {
+		for _, piece := range a.Subtract(o.Axes[i]) {
+			p := rest.clone()
+			p.Axes[i] = piece
+			ret = append(ret, p)
+		}
+		rest.Axes[i], _ = a.Intersect(o.Axes[i])
+	}
+	return ret
+}
+
+// Points returns an iterator over the points in the 2-D Box, in row-major
+// order. It panics if the Box isn't 2-D.
+func (b Box[T]) Points() iter.Seq[d8.Point] {
+	if b.Dims() != 2 {
+		common.Panicf("Points: box is %d-D, not 2-D", b.Dims())
+	}
+	return func(yield func(d8.Point) bool) {
+		for r := b.Axes[0].Lo; r <= b.Axes[0].Hi; r++ {
+			for c := b.Axes[1].Lo; c <= b.Axes[1].Hi; c++ {
+				if !yield(d8.P(int(r), int(c))) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (b Box[T]) String() string {
+	return common.Fjoin(b.Axes, "x", func(a interval.Interval[T]) string { return a.String() })
+}
+
+func (b Box[T]) clone() Box[T] {
+	return Box[T]{append([]interval.Interval[T](nil), b.Axes...)}
+}
+
+func (b Box[T]) mustMatch(o Box[T]) {
+	if b.Dims() != o.Dims() {
+		common.Panicf("mismatched boxes: %d-D and %d-D", b.Dims(), o.Dims())
+	}
+}
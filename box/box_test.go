@@ -0,0 +1,158 @@
+package box
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/glennhartmann/aoclib/grid/d8"
+	"github.com/glennhartmann/aoclib/interval"
+)
+
+func cube(lo, hi int) Box[int] {
+	return FromCorners([]int{lo, lo, lo}, []int{hi, hi, hi})
+}
+
+func TestBox(t *testing.T) {
+	b := cube(0, 3)
+
+	if got := b.Volume(); got != 64 {
+		t.Errorf("%v.Volume() = %d, want 64", b, got)
+	}
+	if got, want := b.String(), "[0, 3]x[0, 3]x[0, 3]"; got != want {
+		t.Errorf("b.String() = %s, want %s", got, want)
+	}
+	if !b.Contains(0, 3, 2) || b.Contains(0, 4, 2) {
+		t.Errorf("%v.Contains() gave wrong results", b)
+	}
+	if !b.ContainsBox(cube(1, 2)) || b.ContainsBox(cube(1, 4)) {
+		t.Errorf("%v.ContainsBox() gave wrong results", b)
+	}
+
+	if got, ok := b.Intersect(cube(2, 5)); !ok || got.String() != cube(2, 3).String() {
+		t.Errorf("%v.Intersect(%v) = %v, %v, want %v, true", b, cube(2, 5), got, ok, cube(2, 3))
+	}
+	if _, ok := b.Intersect(cube(4, 5)); ok {
+		t.Errorf("%v.Intersect(%v) = ok, want no intersection", b, cube(4, 5))
+	}
+}
+
+func TestSubtract(t *testing.T) {
+	b := cube(0, 3)
+
+	tests := []struct {
+		name      string
+		o         Box[int]
+		maxPieces int
+	}{
+		{name: "disjoint", o: cube(5, 6), maxPieces: 1},
+		{name: "corner", o: cube(2, 5), maxPieces: 3},
+		{name: "inside", o: cube(1, 2), maxPieces: 6},
+		{name: "covering", o: cube(-1, 4), maxPieces: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pieces := b.Subtract(test.o)
+			if len(pieces) > test.maxPieces {
+				t.Errorf("%v.Subtract(%v) gave %d pieces, want at most %d", b, test.o, len(pieces), test.maxPieces)
+			}
+
+			var want int
+			if in, ok := b.Intersect(test.o); ok {
+				want = b.Volume() - in.Volume()
+			} else {
+				want = b.Volume()
+			}
+
+			var got int
+			for i, p := range pieces {
+				got += p.Volume()
+				if !b.ContainsBox(p) {
+					t.Errorf("piece %v isn't inside %v", p, b)
+				}
+				if _, ok := p.Intersect(test.o); ok {
+					t.Errorf("piece %v overlaps %v", p, test.o)
+				}
+				for _, q := range pieces[i+1:] {
+					if _, ok := p.Intersect(q); ok {
+						t.Errorf("pieces %v and %v overlap", p, q)
+					}
+				}
+			}
+			if got != want {
+				t.Errorf("%v.Subtract(%v) has volume %d, want %d", b, test.o, got, want)
+			}
+		})
+	}
+}
+
+func TestPoints(t *testing.T) {
+	b := FromPoints(d8.P(1, 5), d8.P(2, 6))
+
+	var got []d8.Point
+	for p := range b.Points() {
+		got = append(got, p)
+	}
+	if want := []d8.Point{d8.P(1, 5), d8.P(1, 6), d8.P(2, 5), d8.P(2, 6)}; !slices.Equal(got, want) {
+		t.Errorf("%v.Points() = %v, want %v", b, got, want)
+	}
+
+	if !b.ContainsPoint(d8.P(2, 5)) || b.ContainsPoint(d8.P(0, 5)) {
+		t.Errorf("%v.ContainsPoint() gave wrong results", b)
+	}
+}
+
+func TestCounter(t *testing.T) {
+	// The small example from 2021 day 22.
+	var c Counter[int64]
+	c.Add(New(interval.New[int64](10, 12), interval.New[int64](10, 12), interval.New[int64](10, 12)))
+	c.Add(New(interval.New[int64](11, 13), interval.New[int64](11, 13), interval.New[int64](11, 13)))
+	c.Remove(New(interval.New[int64](9, 11), interval.New[int64](9, 11), interval.New[int64](9, 11)))
+	c.Add(New(interval.New[int64](10, 10), interval.New[int64](10, 10), interval.New[int64](10, 10)))
+	if got := c.Volume(); got != 39 {
+		t.Errorf("c.Volume() = %d, want 39", got)
+	}
+
+	if got := UnionVolume([]Box[int]{cube(0, 1), cube(1, 2), cube(5, 5)}); got != 8+8-1+1 {
+		t.Errorf("UnionVolume() = %d, want %d", got, 8+8-1+1)
+	}
+}
+
+// TestCounterRandom checks Counter against a brute-force set of points.
+func TestCounterRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	randomAxis := func() interval.Interval[int] {
+		lo := r.Intn(8)
+		return interval.New(lo, lo+r.Intn(4))
+	}
+
+	for round := 0; round < 50; round++ {
+		var c Counter[int]
+		on := make(map[[3]int]bool)
+		for i := 0; i < 10; i++ {
+			b := New(randomAxis(), randomAxis(), randomAxis())
+			add := r.Intn(3) > 0
+			if add {
+				c.Add(b)
+			} else {
+				c.Remove(b)
+			}
+			for x := b.Axes[0].Lo; x <= b.Axes[0].Hi; x++ {
+				for y := b.Axes[1].Lo; y <= b.Axes[1].Hi; y++ {
+					for z := b.Axes[2].Lo; z <= b.Axes[2].Hi; z++ {
+						if add {
+							on[[3]int{x, y, z}] = true
+						} else {
+							delete(on, [3]int{x, y, z})
+						}
+					}
+				}
+			}
+
+			if got := c.Volume(); got != len(on) {
+				t.Fatalf("round %d, step %d: c.Volume() = %d, want %d", round, i, got, len(on))
+			}
+		}
+	}
+}
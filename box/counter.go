@@ -0,0 +1,86 @@
+package box
+
+import (
+	"github.com/glennhartmann/aoclib/common"
+)
+
+// Counter tracks the volume of a union of Boxes that are added and removed in
+// sequence (as in "turn on these cubes, then turn off these ones"), using
+// inclusion–exclusion. It stores each distinct Box with a signed weight, so
+// only intersections are ever computed, rather than the many fragments that
+// repeated Subtract calls produce. The zero value is an empty Counter ready to
+// use.
+type Counter[T common.Integer] struct {
+	weights map[string]*weighted[T]
+}
+
+type weighted[T common.Integer] struct {
+	b Box[T]
+	w int
+}
+
+// UnionVolume returns the number of points in at least one of |boxes|.
+func UnionVolume[T common.Integer](boxes []Box[T]) T {
+	var c Counter[T]
+	for _, b := range boxes {
+		c.Add(b)
+	}
+	return c.Volume()
+}
+
+// Add adds every point in |b| to the Counter.
+func (c *Counter[T]) Add(b Box[T]) { c.update(b, true) }
+
+// Remove removes every point in |b| from the Counter.
+func (c *Counter[T]) Remove(b Box[T]) { c.update(b, false) }
+
+func (c *Counter[T]) update(b Box[T], add bool) {
+	if b.Empty() {
+		return
+	}
+	if c.weights == nil {
+		c.weights = make(map[string]*weighted[T])
+	}
+
+	// Cancel out the part of every existing Box that overlaps |b|, so that
+	// it's counted exactly zero times. Then, if adding, count it once.
+	delta := make(map[string]*weighted[T])
+	for _, e := range c.weights {
+		if in, ok := e.b.Intersect(b); ok {
+			addWeight(delta, in, -e.w)
+		}
+	}
+	if add {
+		addWeight(delta, b, 1)
+	}
+
+	for _, d := range delta {
+		addWeight(c.weights, d.b, d.w)
+	}
+}
+
+func addWeight[T common.Integer](m map[string]*weighted[T], b Box[T], w int) {
+	k := b.String()
+	e, ok := m[k]
+	if !ok {
+		e = &weighted[T]{b: b}
+		m[k] = e
+	}
+	e.w += w
+	if e.w == 0 {
+		delete(m, k)
+	}
+}
+
+// Volume returns the number of points in the Counter.
+func (c *Counter[T]) Volume() T {
+	var ret T
+	for _, e := range c.weights {
+		if e.w > 0 {
+			ret += e.b.Volume() * T(e.w)
+		} else {
+			ret -= e.b.Volume() * T(-e.w)
+		}
+	}
+	return ret
+}
@@ -9,9 +9,15 @@ import (
 	"golang.org/x/exp/constraints"
 )
 
+// Integer is all integer types. It's the same as constraints.Integer, but
+// saves callers from importing golang.org/x/exp/constraints.
+type Integer interface {
+	constraints.Integer
+}
+
 // Real is all non-complex number types.
 type Real interface {
-	constraints.Integer | constraints.Float
+	Integer | constraints.Float
 }
 
 // ConvenientLenable is the set of non-map types than len() makes sense on.
@@ -216,32 +222,167 @@ type Equatable interface {
 	constraints.Complex | constraints.Float | constraints.Integer | ~string | ~bool | ~byte
 }
 
+// SplitSlice splits |slice| into all sub-slices separated by |sep|, like
+// strings.Split. If |sep| is empty, it splits after each element.
 func SplitSlice[T Equatable](slice []T, sep []T) [][]T {
-	ret := make([][]T, 0, len(slice)/5)
-	for {
-		i := SliceIndex(slice, sep)
-		if i == -1 {
-			ret = append(ret, slice)
-			break
+	return SplitSliceN(slice, sep, -1)
+}
+
+// SplitSliceN is like SplitSlice, but returns at most |n| sub-slices, like
+// strings.SplitN. If |n| is 0, it returns nil. If |n| is negative, it returns
+// all sub-slices. The sub-slices share memory with |slice|.
+func SplitSliceN[T Equatable](slice []T, sep []T, n int) [][]T {
+	if n == 0 {
+		return nil
+	}
+	if len(sep) == 0 {
+		return explode(slice, n)
+	}
+
+	var ret [][]T
+	start := 0
+	forEachMatch(slice, sep, false, func(i int) bool {
+		if n > 0 && len(ret) == n-1 {
+			return false
 		}
+		ret = append(ret, slice[start:i:i])
+		start = i + len(sep)
+		return true
+	})
+	return append(ret, slice[start:])
+}
 
-		ret = append(ret, slice[:i])
-		slice = slice[i+1:]
+func explode[T any](slice []T, n int) [][]T {
+	if n < 0 || n > len(slice) {
+		n = len(slice)
+	}
+	ret := make([][]T, 0, n)
+	for i := 0; i < n-1; i++ {
+		ret = append(ret, slice[i:i+1:i+1])
+	}
+	if n > 0 {
+		ret = append(ret, slice[n-1:])
 	}
 	return ret
 }
 
+// SliceIndex returns the index of the first occurrence of |target| in
+// |slice|, or -1 if there isn't one. It runs in O(len(slice) + len(target))
+// time.
 func SliceIndex[T Equatable](slice []T, target []T) int {
-outer:
-	for i := 0; i < len(slice)-len(target)+1; i++ {
-		for j := range target {
-			if slice[i+j] != target[j] {
-				continue outer
+	ret := -1
+	forEachMatch(slice, target, false, func(i int) bool {
+		ret = i
+		return false
+	})
+	return ret
+}
+
+// SliceLastIndex returns the index of the last occurrence of |target| in
+// |slice|, or -1 if there isn't one.
+func SliceLastIndex[T Equatable](slice []T, target []T) int {
+	ret := -1
+	forEachMatch(slice, target, true, func(i int) bool {
+		ret = i
+		return true
+	})
+	return ret
+}
+
+// SliceIndexAll returns the indices of every non-overlapping occurrence of
+// |target| in |slice|, scanning from the start. If |target| is empty, every
+// index from 0 to len(slice) (inclusive) matches.
+func SliceIndexAll[T Equatable](slice []T, target []T) []int {
+	var ret []int
+	forEachMatch(slice, target, false, func(i int) bool {
+		ret = append(ret, i)
+		return true
+	})
+	return ret
+}
+
+// SliceCount returns the number of non-overlapping occurrences of |target| in
+// |slice|, like strings.Count.
+func SliceCount[T Equatable](slice []T, target []T) int {
+	count := 0
+	forEachMatch(slice, target, false, func(int) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+// SliceReplace returns a copy of |slice| with the first |n| non-overlapping
+// occurrences of |old| replaced by |new|, like strings.Replace. If |n| is
+// negative, every occurrence is replaced.
+func SliceReplace[T Equatable](slice, old, new []T, n int) []T {
+	ret := make([]T, 0, len(slice))
+	start, replaced := 0, 0
+	forEachMatch(slice, old, false, func(i int) bool {
+		if n >= 0 && replaced == n {
+			return false
+		}
+		ret = append(ret, slice[start:i]...)
+		ret = append(ret, new...)
+		start = i + len(old)
+		replaced++
+		return true
+	})
+	return append(ret, slice[start:]...)
+}
+
+// forEachMatch calls |f| with the index of each occurrence of |target| in
+// |slice|, in order, until |f| returns false. Matches may overlap only if
+// |overlap| is true. It uses the Knuth-Morris-Pratt algorithm, so it runs in
+// O(len(slice) + len(target)) time.
+func forEachMatch[T Equatable](slice []T, target []T, overlap bool, f func(i int) bool) {
+	if len(target) == 0 {
+		for i := 0; i <= len(slice); i++ {
+			if !f(i) {
+				return
+			}
+		}
+		return
+	}
+
+	fail := kmpTable(target)
+	j := 0 // number of elements of target currently matched
+	for i := range slice {
+		for j > 0 && slice[i] != target[j] {
+			j = fail[j-1]
+		}
+		if slice[i] == target[j] {
+			j++
+		}
+		if j == len(target) {
+			if !f(i - j + 1) {
+				return
 			}
+			if overlap {
+				j = fail[j-1]
+			} else {
+				j = 0
+			}
+		}
+	}
+}
+
+// kmpTable returns the KMP failure function of |pattern|: element i is the
+// length of the longest proper prefix of pattern[:i+1] that's also a suffix
+// of it.
+func kmpTable[T Equatable](pattern []T) []int {
+	fail := make([]int, len(pattern))
+	k := 0
+	for i := 1; i < len(pattern); i++ {
+		for k > 0 && pattern[i] != pattern[k] {
+			k = fail[k-1]
+		}
+		if pattern[i] == pattern[k] {
+			k++
 		}
-		return i
+		fail[i] = k
 	}
-	return -1
+	return fail
 }
 
 func StringSliceToByteSlice2(strs []string) [][]byte {
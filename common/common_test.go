@@ -1,8 +1,13 @@
 package common
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"log/slog"
+	"slices"
 	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -753,3 +758,73 @@ func TestPadToPadding(t *testing.T) {
 		})
 	}
 }
+
+func TestSetLogger(t *testing.T) {
+	defer SetLogger(Logger())
+
+	var buf bytes.Buffer
+	SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	Logger().Debug("hello", "n", 5)
+	if got := buf.String(); !strings.Contains(got, "msg=hello n=5") {
+		t.Errorf("logged %q, want it to contain %q", got, "msg=hello n=5")
+	}
+
+	Quiet()
+	if Logger().Enabled(context.Background(), slog.LevelError) {
+		t.Errorf("Logger().Enabled(LevelError) = true after Quiet(), want false")
+	}
+}
+
+func TestSliceSearch(t *testing.T) {
+	tests := []struct {
+		name   string
+		s, sep string
+	}{
+		{name: "single-element sep", s: "a,b,,c", sep: ","},
+		{name: "multi-element sep", s: "a::b::::c", sep: "::"},
+		{name: "overlapping candidates", s: "aaaaa", sep: "aa"},
+		{name: "partial matches", s: "abababcabab", sep: "ababc"},
+		{name: "sep at ends", s: "--x--", sep: "--"},
+		{name: "no match", s: "abc", sep: "x"},
+		{name: "sep longer than slice", s: "ab", sep: "abc"},
+		{name: "empty slice", s: "", sep: "x"},
+		{name: "empty sep", s: "abc", sep: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, sep := []byte(test.s), []byte(test.sep)
+
+			for _, n := range []int{-1, 0, 1, 2, 3} {
+				got := ByteSlice2ToStringSlice(SplitSliceN(s, sep, n))
+				if want := strings.SplitN(test.s, test.sep, n); !slices.Equal(got, want) {
+					t.Errorf("SplitSliceN(%q, %q, %d) = %q, want %q", test.s, test.sep, n, got, want)
+				}
+
+				if got, want := string(SliceReplace(s, sep, []byte("<>"), n)), strings.Replace(test.s, test.sep, "<>", n); got != want {
+					t.Errorf("SliceReplace(%q, %q, \"<>\", %d) = %q, want %q", test.s, test.sep, n, got, want)
+				}
+			}
+
+			if got, want := ByteSlice2ToStringSlice(SplitSlice(s, sep)), strings.Split(test.s, test.sep); !slices.Equal(got, want) {
+				t.Errorf("SplitSlice(%q, %q) = %q, want %q", test.s, test.sep, got, want)
+			}
+			if got, want := SliceIndex(s, sep), strings.Index(test.s, test.sep); got != want {
+				t.Errorf("SliceIndex(%q, %q) = %d, want %d", test.s, test.sep, got, want)
+			}
+			if got, want := SliceLastIndex(s, sep), strings.LastIndex(test.s, test.sep); got != want {
+				t.Errorf("SliceLastIndex(%q, %q) = %d, want %d", test.s, test.sep, got, want)
+			}
+			if got, want := SliceCount(s, sep), strings.Count(test.s, test.sep); got != want {
+				t.Errorf("SliceCount(%q, %q) = %d, want %d", test.s, test.sep, got, want)
+			}
+			if got := SliceIndexAll(s, sep); len(got) != SliceCount(s, sep) {
+				t.Errorf("SliceIndexAll(%q, %q) = %v, want %d indices", test.s, test.sep, got, SliceCount(s, sep))
+			}
+		})
+	}
+
+	if got, want := SliceIndexAll([]int{1, 2, 1, 2, 1, 2}, []int{2, 1}), []int{1, 3}; !slices.Equal(got, want) {
+		t.Errorf("SliceIndexAll() = %v, want %v", got, want)
+	}
+}
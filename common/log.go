@@ -0,0 +1,52 @@
+package common
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"sync/atomic"
+)
+
+var (
+	logLevel = new(slog.LevelVar)
+	logger   atomic.Pointer[slog.Logger]
+)
+
+func init() {
+	logLevel.Set(slog.LevelDebug)
+	logger.Store(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: logLevel})))
+}
+
+// Logger returns the logger used for all debug output in this library. By
+// default, it writes everything (including debug-level messages) to stderr.
+func Logger() *slog.Logger {
+	return logger.Load()
+}
+
+// SetLogger replaces the logger used for all debug output in this library.
+// Passing nil silences all output.
+func SetLogger(l *slog.Logger) {
+	if l == nil {
+		l = slog.New(discardHandler{})
+	}
+	logger.Store(l)
+}
+
+// SetLogLevel sets the minimum level of messages written by the default
+// logger. It has no effect on loggers passed to SetLogger.
+func SetLogLevel(level slog.Level) {
+	logLevel.Set(level)
+}
+
+// Quiet silences all debug output in this library. It's the same as
+// SetLogger(nil).
+func Quiet() {
+	SetLogger(nil)
+}
+
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (d discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return d }
+func (d discardHandler) WithGroup(string) slog.Handler           { return d }
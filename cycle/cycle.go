@@ -0,0 +1,148 @@
+// Package cycle implements cycle detection for deterministic step functions,
+// and uses it to find the state (or a value derived from it) after a huge
+// number of steps without simulating them all.
+package cycle
+
+import (
+	"github.com/glennhartmann/aoclib/common"
+)
+
+// Cycle describes a sequence of states s_0, s_1, ... (where s_i+1 = step(s_i))
+// that eventually repeats: s_i == s_i+Len for every i >= Start.
+type Cycle struct {
+	Start, Len int
+}
+
+// Index returns the smallest step number whose state is the same as the
+// state after |n| steps.
+func (c Cycle) Index(n int) int {
+	if n < c.Start {
+		return n
+	}
+	return c.Start + (n-c.Start)%c.Len
+}
+
+// Detect steps from |initial| until a state repeats, and returns the
+// resulting Cycle. Every state is kept in a map, so this uses O(Start + Len)
+// memory. It never returns if the sequence doesn't repeat.
+func Detect[S comparable](initial S, step func(S) S) Cycle {
+	return DetectFunc(initial, step, identity[S])
+}
+
+// DetectFunc is like Detect, but for states that aren't comparable (or
+// shouldn't be compared directly). Two states are considered equal if |key|
+// returns the same value for both.
+func DetectFunc[S any, K comparable](initial S, step func(S) S, key func(S) K) Cycle {
+	c, _ := run(initial, step, key, -1, func(int, S) {})
+	return c
+}
+
+// Brent is like Detect, but uses Brent's algorithm, which only needs O(1)
+// memory at the cost of calling |step| roughly three times as often.
+func Brent[S comparable](initial S, step func(S) S) Cycle {
+	// Find the cycle length by racing a hare ahead of a tortoise that teleports
+	// to the hare at every power of two.
+	power, length := 1, 1
+	tortoise, hare := initial, step(initial)
+	for tortoise != hare {
+		if power == length {
+			tortoise = hare
+			power *= 2
+			length = 0
+		}
+		hare = step(hare)
+		length++
+	}
+
+	// Then find the start by moving two pointers |length| apart in lockstep.
+	tortoise, hare = initial, initial
+	for i := 0; i < length; i++ {
+		hare = step(hare)
+	}
+	start := 0
+	for tortoise != hare {
+		tortoise, hare = step(tortoise), step(hare)
+		start++
+	}
+
+	return Cycle{Start: start, Len: length}
+}
+
+// Simulate returns the state after applying |step| to |initial| |n| times.
+// If a state repeats before then, the rest of the steps are skipped by
+// jumping ahead a whole number of cycles.
+func Simulate[S comparable](initial S, step func(S) S, n int) S {
+	return SimulateFunc(initial, step, identity[S], n)
+}
+
+// SimulateFunc is like Simulate, but compares states using |key|, as in
+// DetectFunc.
+func SimulateFunc[S any, K comparable](initial S, step func(S) S, key func(S) K, n int) S {
+	return Value(initial, step, key, identity[S], n)
+}
+
+// Value is like SimulateFunc, but returns value(s) for the state s after |n|
+// steps. It panics if |n| is negative.
+//
+// It keeps key(s) and value(s) for every state visited, so memory use is
+// O(Start + Len) keys plus values. Only the current state itself is kept, so
+// a small key and value save memory when states are large. (Simulate's keys
+// and values are the full states.)
+func Value[S any, K comparable, V any](initial S, step func(S) S, key func(S) K, value func(S) V, n int) V {
+	mustBeNonNegative(n)
+	var vals []V
+	c, found := run(initial, step, key, n, func(_ int, s S) { vals = append(vals, value(s)) })
+	if !found {
+		return vals[n]
+	}
+	return vals[c.Index(n)]
+}
+
+// Extrapolate is like Value, but for values that aren't periodic themselves,
+// but instead grow by the same amount every time the states go around the
+// cycle (such as the height of a tower of falling blocks). The value after
+// |n| steps is extrapolated from the first time around the cycle.
+func Extrapolate[S any, K comparable, V common.Real](initial S, step func(S) S, key func(S) K, value func(S) V, n int) V {
+	mustBeNonNegative(n)
+	var vals []V
+	c, found := run(initial, step, key, n, func(_ int, s S) { vals = append(vals, value(s)) })
+	if !found {
+		return vals[n]
+	}
+
+	perCycle := vals[c.Start+c.Len] - vals[c.Start]
+	cycles := (n - c.Start) / c.Len
+	return vals[c.Index(n)] + V(cycles)*perCycle
+}
+
+func mustBeNonNegative(n int) {
+	if n < 0 {
+		common.Panicf("negative step count: %d", n)
+	}
+}
+
+// run calls |visit| with each state in turn, starting with |initial|, until
+// either a state repeats or |n| steps have been taken (if |n| isn't
+// negative). It returns the Cycle, and whether or not one was found. If one
+// was, |visit| has been called for the first repeated state too, so it's
+// been called Start+Len+1 times.
+func run[S any, K comparable](initial S, step func(S) S, key func(S) K, n int, visit func(i int, s S)) (Cycle, bool) {
+	seen := make(map[K]int)
+	s := initial
+	for i := 0; ; i++ {
+		visit(i, s)
+
+		k := key(s)
+		if j, ok := seen[k]; ok {
+			return Cycle{Start: j, Len: i - j}, true
+		}
+		if i == n {
+			return Cycle{}, false
+		}
+		seen[k] = i
+
+		s = step(s)
+	}
+}
+
+func identity[S any](s S) S { return s }
@@ -0,0 +1,84 @@
+package cycle
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// step is x -> x^2 + 1 (mod 97), which starting from 3 enters a cycle after
+// a short tail.
+func step(x int) int { return (x*x + 1) % 97 }
+
+func bruteForce(x, n int) int {
+	for i := 0; i < n; i++ {
+		x = step(x)
+	}
+	return x
+}
+
+func TestDetect(t *testing.T) {
+	for _, initial := range []int{0, 3, 42} {
+		c := Detect(initial, step)
+		if c.Len <= 0 {
+			t.Fatalf("Detect(%d) = %+v, want positive length", initial, c)
+		}
+		if a, b := bruteForce(initial, c.Start), bruteForce(initial, c.Start+c.Len); a != b {
+			t.Errorf("Detect(%d) = %+v, but states %d and %d differ", initial, c, a, b)
+		}
+		if c.Start > 0 {
+			if a, b := bruteForce(initial, c.Start-1), bruteForce(initial, c.Start-1+c.Len); a == b {
+				t.Errorf("Detect(%d) = %+v, but the cycle starts earlier", initial, c)
+			}
+		}
+
+		if got := Brent(initial, step); got != c {
+			t.Errorf("Brent(%d) = %+v, want %+v", initial, got, c)
+		}
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	for n := 0; n < 200; n++ {
+		if got, want := Simulate(3, step, n), bruteForce(3, n); got != want {
+			t.Errorf("Simulate(3, %d) = %d, want %d", n, got, want)
+		}
+	}
+
+	c := Detect(3, step)
+	const n = 1_000_000_000
+	if got, want := Simulate(3, step, n), bruteForce(3, c.Index(n)); got != want {
+		t.Errorf("Simulate(3, %d) = %d, want %d", n, got, want)
+	}
+}
+
+func TestSimulateFunc(t *testing.T) {
+	// Rotate a slice (which isn't comparable) one place to the left.
+	rotate := func(s []string) []string { return append(slices.Clone(s[1:]), s[0]) }
+	key := func(s []string) string { return strings.Join(s, "") }
+
+	got := SimulateFunc([]string{"a", "b", "c"}, rotate, key, 1_000_000_000)
+	if want := []string{"b", "c", "a"}; !slices.Equal(got, want) {
+		t.Errorf("SimulateFunc() = %q, want %q", got, want)
+	}
+
+	if got := Value([]string{"a", "b", "c"}, rotate, key, func(s []string) string { return s[0] }, 5); got != "c" {
+		t.Errorf("Value() = %q, want %q", got, "c")
+	}
+}
+
+func TestExtrapolate(t *testing.T) {
+	// The state cycles through 0, 1, 2, while each step adds the state plus
+	// one to a running total, so the total grows by 6 every cycle.
+	type state struct{ phase, total int }
+	st := func(s state) state { return state{(s.phase + 1) % 3, s.total + s.phase + 1} }
+	key := func(s state) int { return s.phase }
+	total := func(s state) int { return s.total }
+
+	for _, n := range []int{0, 1, 2, 3, 4, 100, 1_000_000_000} {
+		want := n/3*6 + []int{0, 1, 3}[n%3]
+		if got := Extrapolate(state{}, st, key, total, n); got != want {
+			t.Errorf("Extrapolate(%d) = %d, want %d", n, got, want)
+		}
+	}
+}
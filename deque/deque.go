@@ -0,0 +1,330 @@
+// Package deque implements a fully generic double-ended queue, backed by a
+// growable ring buffer. It follows the same conventions as the stack and
+// queue packages.
+package deque
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+
+	"github.com/glennhartmann/aoclib/common"
+	"github.com/glennhartmann/aoclib/internal/containerfmt"
+	"github.com/glennhartmann/aoclib/internal/errs"
+	"github.com/glennhartmann/aoclib/internal/ringbuffer"
+)
+
+var (
+	// ErrEmpty is returned when popping or peeking from an empty deque.
+	ErrEmpty = errs.ErrEmpty
+
+	// ErrOutOfRange is returned when accessing an index outside the deque,
+	// or popping more elements than it holds.
+	ErrOutOfRange = errs.ErrOutOfRange
+)
+
+// Deque is a generic double-ended queue. Pushes and pops at either end are
+// amortized O(1).
+type Deque[T any] struct {
+	rb *ringbuffer.RingBuffer[T]
+}
+
+// NewDeque creates an empty Deque.
+func NewDeque[T any]() *Deque[T] {
+	return NewDequeN[T](0)
+}
+
+// NewDequeN creates an empty Deque with |size| elements worth of preallocated
+// memory.
+func NewDequeN[T any](size int) *Deque[T] {
+	return &Deque[T]{ringbuffer.New[T](size)}
+}
+
+// Size returns the number of elements in the deque.
+func (d *Deque[T]) Size() int {
+	return d.rb.Len()
+}
+
+// Empty returns whether or not the deque is empty.
+func (d *Deque[T]) Empty() bool {
+	return d.Size() == 0
+}
+
+// Cap returns the number of elements the deque can hold without allocating
+// more memory.
+func (d *Deque[T]) Cap() int {
+	return d.rb.Cap()
+}
+
+// Reserve ensures that the deque can hold at least |n| elements without
+// allocating more memory, and that it won't automatically release memory
+// below that.
+func (d *Deque[T]) Reserve(n int) {
+	d.rb.Reserve(n)
+}
+
+// Shrink releases any reserved memory, and reduces the capacity of the deque
+// to the number of elements it currently holds.
+func (d *Deque[T]) Shrink() {
+	d.rb.Shrink()
+}
+
+// PushFront pushes an item onto the front of the deque.
+func (d *Deque[T]) PushFront(v T) {
+	d.rb.PushFront(v)
+}
+
+// PushBack pushes an item onto the back of the deque.
+func (d *Deque[T]) PushBack(v T) {
+	d.rb.PushBack(v)
+}
+
+// PushFrontN pushes N items onto the front of the deque, one at a time, so
+// the last one ends up at the front.
+func (d *Deque[T]) PushFrontN(v ...T) {
+	for _, i := range v {
+		d.PushFront(i)
+	}
+}
+
+// PushBackN pushes N items onto the back of the deque, one at a time, so the
+// last one ends up at the back.
+func (d *Deque[T]) PushBackN(v ...T) {
+	for _, i := range v {
+		d.PushBack(i)
+	}
+}
+
+// PopFront pops an item from the front of the deque. If the deque is empty, an
+// error is returned instead and the deque remains unmodified.
+func (d *Deque[T]) PopFront() (T, error) {
+	if d.Empty() {
+		var r T
+		return r, fmt.Errorf("deque is %w", ErrEmpty)
+	}
+	return d.rb.PopFront(), nil
+}
+
+// PopBack pops an item from the back of the deque. If the deque is empty, an
+// error is returned instead and the deque remains unmodified.
+func (d *Deque[T]) PopBack() (T, error) {
+	if d.Empty() {
+		var r T
+		return r, fmt.Errorf("deque is %w", ErrEmpty)
+	}
+	return d.rb.PopBack(), nil
+}
+
+// PopFrontN pops N items from the front of the deque. If the deque has fewer
+// than N elements, an error is returned instead and the deque remains
+// unmodified.
+func (d *Deque[T]) PopFrontN(n int) ([]T, error) {
+	return d.popN(n, d.PopFront)
+}
+
+// PopBackN pops N items from the back of the deque. If the deque has fewer
+// than N elements, an error is returned instead and the deque remains
+// unmodified.
+func (d *Deque[T]) PopBackN(n int) ([]T, error) {
+	return d.popN(n, d.PopBack)
+}
+
+func (d *Deque[T]) popN(n int, pop func() (T, error)) ([]T, error) {
+	if n > d.Size() {
+		return nil, fmt.Errorf("can't pop %d elements - there are only %d in the deque: %w", n, d.Size(), ErrOutOfRange)
+	}
+	r := make([]T, 0, n)
+	for i := 0; i < n; i++ {
+		v, err := pop()
+		if err != nil {
+			panic("this really shouldn't happen")
+		}
+		r = append(r, v)
+	}
+	return r, nil
+}
+
+// PeekFront returns the item at the front of the deque without removing it.
+// If the deque is empty, an error is returned instead.
+func (d *Deque[T]) PeekFront() (T, error) {
+	return d.At(0)
+}
+
+// PeekBack returns the item at the back of the deque without removing it. If
+// the deque is empty, an error is returned instead.
+func (d *Deque[T]) PeekBack() (T, error) {
+	return d.At(d.Size() - 1)
+}
+
+// At returns the item at index |i|, where 0 is the front of the deque. If |i|
+// is out of range, an error is returned instead.
+func (d *Deque[T]) At(i int) (T, error) {
+	if i < 0 || i >= d.Size() {
+		var r T
+		if d.Empty() {
+			return r, fmt.Errorf("deque is %w", ErrEmpty)
+		}
+		return r, fmt.Errorf("wanted item %d; deque only contains %d items: %w", i, d.Size(), ErrOutOfRange)
+	}
+	return d.rb.At(i), nil
+}
+
+// Set replaces the item at index |i|, where 0 is the front of the deque. If
+// |i| is out of range, an error is returned instead.
+func (d *Deque[T]) Set(i int, v T) error {
+	if i < 0 || i >= d.Size() {
+		return fmt.Errorf("wanted item %d; deque only contains %d items: %w", i, d.Size(), ErrOutOfRange)
+	}
+	d.rb.Set(i, v)
+	return nil
+}
+
+// MustPopFront is like PopFront, but panics if the deque is empty.
+func (d *Deque[T]) MustPopFront() T {
+	v, err := d.PopFront()
+	if err != nil {
+		common.Panicf("MustPopFront: %v", err)
+	}
+	return v
+}
+
+// MustPopBack is like PopBack, but panics if the deque is empty.
+func (d *Deque[T]) MustPopBack() T {
+	v, err := d.PopBack()
+	if err != nil {
+		common.Panicf("MustPopBack: %v", err)
+	}
+	return v
+}
+
+// MustPopFrontN is like PopFrontN, but panics if the deque has fewer than N
+// elements.
+func (d *Deque[T]) MustPopFrontN(n int) []T {
+	v, err := d.PopFrontN(n)
+	if err != nil {
+		common.Panicf("MustPopFrontN: %v", err)
+	}
+	return v
+}
+
+// MustPopBackN is like PopBackN, but panics if the deque has fewer than N
+// elements.
+func (d *Deque[T]) MustPopBackN(n int) []T {
+	v, err := d.PopBackN(n)
+	if err != nil {
+		common.Panicf("MustPopBackN: %v", err)
+	}
+	return v
+}
+
+// MustPeekFront is like PeekFront, but panics if the deque is empty.
+func (d *Deque[T]) MustPeekFront() T {
+	v, err := d.PeekFront()
+	if err != nil {
+		common.Panicf("MustPeekFront: %v", err)
+	}
+	return v
+}
+
+// MustPeekBack is like PeekBack, but panics if the deque is empty.
+func (d *Deque[T]) MustPeekBack() T {
+	v, err := d.PeekBack()
+	if err != nil {
+		common.Panicf("MustPeekBack: %v", err)
+	}
+	return v
+}
+
+// MustAt is like At, but panics if |i| is out of range.
+func (d *Deque[T]) MustAt(i int) T {
+	v, err := d.At(i)
+	if err != nil {
+		common.Panicf("MustAt: %v", err)
+	}
+	return v
+}
+
+// Join creates a string by combining each element from the deque, from front
+// to back, with |sep| between each pair.
+func (d *Deque[T]) Join(sep string) string {
+	var sb strings.Builder
+	for i, v := range d.All() {
+		if i > 0 {
+			sb.WriteString(sep)
+		}
+		sb.WriteString(fmt.Sprintf("%v", v))
+	}
+	return sb.String()
+}
+
+// JoinLabeled is like Join, but labels which end of the deque is which (e.g.
+// "front [1, 2, 3] back").
+func (d *Deque[T]) JoinLabeled(sep string) string {
+	return fmt.Sprintf("front [%s] back", d.Join(sep))
+}
+
+// String implements fmt.Stringer. It lists the elements from front to back,
+// labelling the ends.
+func (d *Deque[T]) String() string {
+	return d.JoinLabeled(", ")
+}
+
+// Details returns the size and capacity of the deque, as printed by %+v.
+func (d *Deque[T]) Details() string {
+	return fmt.Sprintf("%d items, capacity %d", d.Size(), d.Cap())
+}
+
+// Format implements fmt.Formatter. %v prints the same as String, %+v adds the
+// size and capacity, and %#v prints a Go-syntax representation.
+func (d *Deque[T]) Format(f fmt.State, verb rune) {
+	containerfmt.Format(f, verb, d, "deque.Deque", d.Values())
+}
+
+// All returns an iterator over the indices and values of the deque, from front
+// to back. The deque must not be modified during iteration.
+func (d *Deque[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < d.Size(); i++ {
+			if !yield(i, d.rb.At(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the indices and values of the deque, from
+// back to front. The deque must not be modified during iteration.
+func (d *Deque[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := d.Size() - 1; i >= 0; i-- {
+			if !yield(i, d.rb.At(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values of the deque, from front to back.
+func (d *Deque[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range d.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that pops elements from the front of the deque
+// until it is empty or iteration stops. Elements pushed during iteration
+// (at either end) will also be popped, which makes Drain convenient for 0-1
+// BFS.
+func (d *Deque[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for !d.Empty() {
+			if !yield(d.rb.PopFront()) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package deque
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func setupDeque() *Deque[string] {
+	d := NewDeque[string]()
+	d.PushBackN("b", "c")
+	d.PushFrontN("a", "0")
+	return d
+}
+
+func TestDequePushPop(t *testing.T) {
+	d := setupDeque()
+
+	if got, want := d.Join(","), "0,a,b,c"; got != want {
+		t.Errorf("d.Join(\",\") = %q, want %q", got, want)
+	}
+
+	if got, err := d.PeekFront(); err != nil || got != "0" {
+		t.Errorf("d.PeekFront() = %q, %v, want \"0\", nil", got, err)
+	}
+	if got, err := d.PeekBack(); err != nil || got != "c" {
+		t.Errorf("d.PeekBack() = %q, %v, want \"c\", nil", got, err)
+	}
+	if got, err := d.At(2); err != nil || got != "b" {
+		t.Errorf("d.At(2) = %q, %v, want \"b\", nil", got, err)
+	}
+	if _, err := d.At(4); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("d.At(4) = %v, want ErrOutOfRange", err)
+	}
+
+	if got, err := d.PopBackN(2); err != nil || !slices.Equal(got, []string{"c", "b"}) {
+		t.Errorf("d.PopBackN(2) = %v, %v, want [c b], nil", got, err)
+	}
+	if _, err := d.PopFrontN(3); err == nil {
+		t.Errorf("d.PopFrontN(3) = nil error, want error")
+	}
+	if got, err := d.PopFront(); err != nil || got != "0" {
+		t.Errorf("d.PopFront() = %q, %v, want \"0\", nil", got, err)
+	}
+	if got, err := d.PopBack(); err != nil || got != "a" {
+		t.Errorf("d.PopBack() = %q, %v, want \"a\", nil", got, err)
+	}
+
+	if !d.Empty() {
+		t.Errorf("d.Empty() = false, want true")
+	}
+	if _, err := d.PopFront(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("d.PopFront() on empty deque = %v, want ErrEmpty", err)
+	}
+	if _, err := d.PeekBack(); err == nil {
+		t.Errorf("d.PeekBack() on empty deque = nil error, want error")
+	}
+}
+
+func TestDequeIterators(t *testing.T) {
+	d := setupDeque()
+
+	if got, want := slices.Collect(d.Values()), []string{"0", "a", "b", "c"}; !slices.Equal(got, want) {
+		t.Errorf("slices.Collect(d.Values()) = %v, want %v", got, want)
+	}
+
+	var got []string
+	for i, v := range d.Backward() {
+		if want, _ := d.At(i); v != want {
+			t.Errorf("d.Backward() yielded (%d, %q), want (%d, %q)", i, v, i, want)
+		}
+		got = append(got, v)
+	}
+	if want := []string{"c", "b", "a", "0"}; !slices.Equal(got, want) {
+		t.Errorf("d.Backward() = %v, want %v", got, want)
+	}
+}
+
+func TestDequeZeroOneBFS(t *testing.T) {
+	// 0-1 BFS over a line of cells, where moving right is free and moving left
+	// costs 1.
+	const n = 10
+	dist := make([]int, n)
+	for i := range dist {
+		dist[i] = n * n
+	}
+	dist[5] = 0
+
+	d := NewDeque[int]()
+	d.PushBack(5)
+	for c := range d.Drain() {
+		if c+1 < n && dist[c] < dist[c+1] {
+			dist[c+1] = dist[c]
+			d.PushFront(c + 1)
+		}
+		if c-1 >= 0 && dist[c]+1 < dist[c-1] {
+			dist[c-1] = dist[c] + 1
+			d.PushBack(c - 1)
+		}
+	}
+
+	if want := []int{5, 4, 3, 2, 1, 0, 0, 0, 0, 0}; !slices.Equal(dist, want) {
+		t.Errorf("dist = %v, want %v", dist, want)
+	}
+}
+
+func TestDequeFormat(t *testing.T) {
+	d := NewDeque[int]()
+	d.PushBackN(1, 2, 3)
+
+	if got, want := fmt.Sprintf("%v", d), "front [1, 2, 3] back"; got != want {
+		t.Errorf("fmt.Sprintf(\"%%v\", d) = %q, want %q", got, want)
+	}
+	if got, want := fmt.Sprintf("%#v", d), "deque.Deque[int]{1, 2, 3}"; got != want {
+		t.Errorf("fmt.Sprintf(\"%%#v\", d) = %q, want %q", got, want)
+	}
+}
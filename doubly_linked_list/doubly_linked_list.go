@@ -2,13 +2,34 @@ package doubly_linked_list
 
 import (
 	"fmt"
+	"iter"
 	"strings"
+
+	"github.com/glennhartmann/aoclib/common"
+	"github.com/glennhartmann/aoclib/internal/containerfmt"
+	"github.com/glennhartmann/aoclib/internal/errs"
 )
 
-// cycles not supported
+var (
+	// ErrEmpty is returned when popping or peeking from an empty list or
+	// ring.
+	ErrEmpty = errs.ErrEmpty
+
+	// ErrNotInList is returned when a node isn't part of the list it's being
+	// used with.
+	ErrNotInList = errs.ErrNotInList
+
+	// ErrOutOfRange is returned when accessing an index outside the list.
+	ErrOutOfRange = errs.ErrOutOfRange
+)
+
+// cycles not supported - see Ring for a circular list
 type DLL[T any] struct {
 	head, tail *Node[T]
 	length     int64
+
+	indexed bool
+	root    *ordNode[T]
 }
 
 func NewDLL[T any]() *DLL[T] { return &DLL[T]{} }
@@ -30,12 +51,100 @@ func (d *DLL[T]) String() string {
 	return sb.String()
 }
 
+// Details returns the mode of the list, as printed by %+v. (String already
+// includes the size.)
+func (d *DLL[T]) Details() string {
+	if d.indexed {
+		return "indexed"
+	}
+	return "not indexed"
+}
+
+// Format implements fmt.Formatter. %v prints the same as String, %+v adds
+// the mode, and %#v prints a Go-syntax representation.
+func (d *DLL[T]) Format(f fmt.State, verb rune) {
+	containerfmt.Format(f, verb, d, "doubly_linked_list.DLL", d.Values())
+}
+
+// All returns an iterator over the indices and values of the list, from head
+// to tail.
+func (d *DLL[T]) All() iter.Seq2[int64, T] {
+	return func(yield func(int64, T) bool) {
+		i := int64(0)
+		for n := d.head; n != nil; n = n.next {
+			if !yield(i, n.val) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Backward returns an iterator over the indices and values of the list, from
+// tail to head.
+func (d *DLL[T]) Backward() iter.Seq2[int64, T] {
+	return func(yield func(int64, T) bool) {
+		i := d.length - 1
+		for n := d.tail; n != nil; n = n.prev {
+			if !yield(i, n.val) {
+				return
+			}
+			i--
+		}
+	}
+}
+
+// Values returns an iterator over the values of the list, from head to tail.
+func (d *DLL[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := d.head; n != nil; n = n.next {
+			if !yield(n.val) {
+				return
+			}
+		}
+	}
+}
+
+// Nodes returns an iterator over the nodes of the list, from head to tail. It
+// is safe to remove the current node during iteration.
+func (d *DLL[T]) Nodes() iter.Seq[*Node[T]] {
+	return func(yield func(*Node[T]) bool) {
+		for n := d.head; n != nil; {
+			next := n.next
+			if !yield(n) {
+				return
+			}
+			n = next
+		}
+	}
+}
+
+// Drain returns an iterator that pops values from the head of the list until
+// it is empty or iteration stops.
+func (d *DLL[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for d.head != nil {
+			v, err := d.PopHead()
+			if err != nil {
+				panic("this really shouldn't happen")
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
 func (d *DLL[T]) Head() *Node[T] { return d.head }
 func (d *DLL[T]) Tail() *Node[T] { return d.tail }
 func (d *DLL[T]) Len() int64     { return d.length }
 
 func (d *DLL[T]) PushHead(val T) {
-	n := NewNode(val)
+	d.PushNodeHead(NewNode(val))
+}
+
+func (d *DLL[T]) PushNodeHead(n *Node[T]) {
+	n.prev = nil
 	n.next = d.head
 
 	if d.head != nil {
@@ -48,10 +157,15 @@ func (d *DLL[T]) PushHead(val T) {
 	}
 
 	d.length++
+	d.indexInsert(n, 0)
 }
 
 func (d *DLL[T]) PushTail(val T) {
-	n := NewNode(val)
+	d.PushNodeTail(NewNode(val))
+}
+
+func (d *DLL[T]) PushNodeTail(n *Node[T]) {
+	n.next = nil
 	n.prev = d.tail
 
 	if d.tail != nil {
@@ -64,6 +178,7 @@ func (d *DLL[T]) PushTail(val T) {
 	}
 
 	d.length++
+	d.indexInsert(n, d.length-1)
 }
 
 func (d *DLL[T]) PopHead() (T, error) {
@@ -71,9 +186,7 @@ func (d *DLL[T]) PopHead() (T, error) {
 	if err != nil {
 		return v, fmt.Errorf("d.PeekHead(): %w", err)
 	}
-	d.head = d.head.next
-
-	d.length--
+	d.unlink(d.head)
 
 	return v, nil
 }
@@ -83,9 +196,7 @@ func (d *DLL[T]) PopTail() (T, error) {
 	if err != nil {
 		return v, fmt.Errorf("d.PeekTail(): %w", err)
 	}
-	d.tail = d.tail.next
-
-	d.length--
+	d.unlink(d.tail)
 
 	return v, nil
 }
@@ -93,33 +204,24 @@ func (d *DLL[T]) PopTail() (T, error) {
 func (d *DLL[T]) PeekHead() (T, error) {
 	if d.head == nil {
 		var r T
-		return r, fmt.Errorf("d.head == nil")
+		return r, fmt.Errorf("d.head == nil -- list is %w", ErrEmpty)
 	}
 	return d.head.val, nil
 }
 
 func (d *DLL[T]) PeekHeadN(n int64) (T, error) {
-	if n >= d.length {
+	node, err := d.NodeAt(n)
+	if err != nil {
 		var r T
-		return r, fmt.Errorf("wanted item %d; list only contains %d items", n, d.length)
-	}
-
-	// TODO: could be optimized by going backwards if it's closer to the end
-	node := d.head
-	for i := int64(0); i < n; i++ {
-		if node == nil {
-			panic("ran off the end of the list")
-		}
-		node = node.next
+		return r, err
 	}
-
 	return node.val, nil
 }
 
 func (d *DLL[T]) PeekTail() (T, error) {
 	if d.tail == nil {
 		var r T
-		return r, fmt.Errorf("d.tail == nil")
+		return r, fmt.Errorf("d.tail == nil -- list is %w", ErrEmpty)
 	}
 	return d.tail.val, nil
 }
@@ -127,19 +229,127 @@ func (d *DLL[T]) PeekTail() (T, error) {
 func (d *DLL[T]) PeekTailN(n int64) (T, error) {
 	if n >= d.length {
 		var r T
-		return r, fmt.Errorf("wanted item %d; list only contains %d items", n, d.length)
+		return r, fmt.Errorf("wanted item %d; list only contains %d items: %w", n, d.length, ErrOutOfRange)
 	}
+	return d.PeekHeadN(d.length - n - 1)
+}
 
-	// TODO: could be optimized by going forwards if it's closer to the start
-	node := d.tail
-	for i := int64(0); i < n; i++ {
-		if node == nil {
-			panic("ran off the start of the list")
+// NodeAt returns the node at index |i|, counting from the head. In indexed
+// mode this takes O(log n) time; otherwise it walks from whichever end of the
+// list is closer.
+func (d *DLL[T]) NodeAt(i int64) (*Node[T], error) {
+	if i < 0 || i >= d.length {
+		return nil, fmt.Errorf("wanted item %d; list only contains %d items: %w", i, d.length, ErrOutOfRange)
+	}
+	return d.nodeAt(i), nil
+}
+
+func (d *DLL[T]) nodeAt(i int64) *Node[T] {
+	if d.indexed {
+		return d.indexAt(i)
+	}
+
+	if i <= d.length/2 {
+		node := d.head
+		for j := int64(0); j < i; j++ {
+			node = node.next
 		}
+		return node
+	}
+
+	node := d.tail
+	for j := d.length - 1; j > i; j-- {
 		node = node.prev
 	}
+	return node
+}
 
-	return node.val, nil
+// IndexOf returns the index of |n|, counting from the head. In indexed mode
+// this takes O(log n) time; otherwise it searches from both ends of the list
+// at once, so it takes time proportional to the distance from |n| to the
+// closer end.
+func (d *DLL[T]) IndexOf(n *Node[T]) (int64, error) {
+	if d.indexed {
+		if i, ok := d.indexOf(n); ok {
+			return i, nil
+		}
+		return -1, ErrNotInList
+	}
+
+	fwd, bwd := d.head, d.tail
+	for i := int64(0); fwd != nil && i <= d.length/2; i++ {
+		if fwd == n {
+			return i, nil
+		}
+		if bwd == n {
+			return d.length - i - 1, nil
+		}
+		fwd, bwd = fwd.next, bwd.prev
+	}
+	return -1, ErrNotInList
+}
+
+// MustPopHead is like PopHead, but panics if the list is empty.
+func (d *DLL[T]) MustPopHead() T {
+	v, err := d.PopHead()
+	if err != nil {
+		common.Panicf("MustPopHead: %v", err)
+	}
+	return v
+}
+
+// MustPopTail is like PopTail, but panics if the list is empty.
+func (d *DLL[T]) MustPopTail() T {
+	v, err := d.PopTail()
+	if err != nil {
+		common.Panicf("MustPopTail: %v", err)
+	}
+	return v
+}
+
+// MustPeekHead is like PeekHead, but panics if the list is empty.
+func (d *DLL[T]) MustPeekHead() T {
+	v, err := d.PeekHead()
+	if err != nil {
+		common.Panicf("MustPeekHead: %v", err)
+	}
+	return v
+}
+
+// MustPeekHeadN is like PeekHeadN, but panics if |n| is out of range.
+func (d *DLL[T]) MustPeekHeadN(n int64) T {
+	v, err := d.PeekHeadN(n)
+	if err != nil {
+		common.Panicf("MustPeekHeadN: %v", err)
+	}
+	return v
+}
+
+// MustPeekTail is like PeekTail, but panics if the list is empty.
+func (d *DLL[T]) MustPeekTail() T {
+	v, err := d.PeekTail()
+	if err != nil {
+		common.Panicf("MustPeekTail: %v", err)
+	}
+	return v
+}
+
+// MustPeekTailN is like PeekTailN, but panics if |n| is out of range.
+func (d *DLL[T]) MustPeekTailN(n int64) T {
+	v, err := d.PeekTailN(n)
+	if err != nil {
+		common.Panicf("MustPeekTailN: %v", err)
+	}
+	return v
+}
+
+// MustNodeAt is like NodeAt, but panics if |i| is out of range.
+func (d *DLL[T]) MustNodeAt(i int64) *Node[T] {
+	n, err := d.NodeAt(i)
+	if err != nil {
+		common.Panicf("MustNodeAt: %v", err)
+	}
+	return n
 }
 
 func (d *DLL[T]) PeekHeadNode() *Node[T] { return d.head }
@@ -151,7 +361,14 @@ func (d *DLL[T]) InsertAfter(val T, n *Node[T]) error {
 
 func (d *DLL[T]) InsertNodeAfter(newN, n *Node[T]) error {
 	if n.next == nil && d.tail != n {
-		return fmt.Errorf("n.next == nil && d.tail != n -- n is not part of this list?")
+		return fmt.Errorf("n.next == nil && d.tail != n -- %w", ErrNotInList)
+	}
+	var i int64
+	if d.indexed {
+		var err error
+		if i, err = d.IndexOf(n); err != nil {
+			return fmt.Errorf("d.IndexOf(): %w", err)
+		}
 	}
 
 	newN.next = n.next
@@ -165,6 +382,7 @@ func (d *DLL[T]) InsertNodeAfter(newN, n *Node[T]) error {
 	}
 
 	d.length++
+	d.indexInsert(newN, i+1)
 
 	return nil
 }
@@ -175,7 +393,14 @@ func (d *DLL[T]) InsertBefore(val T, n *Node[T]) error {
 
 func (d *DLL[T]) InsertNodeBefore(newN, n *Node[T]) error {
 	if n.prev == nil && d.head != n {
-		return fmt.Errorf("n.prev == nil && d.head != n -- n is not part of this list?")
+		return fmt.Errorf("n.prev == nil && d.head != n -- %w", ErrNotInList)
+	}
+	var i int64
+	if d.indexed {
+		var err error
+		if i, err = d.IndexOf(n); err != nil {
+			return fmt.Errorf("d.IndexOf(): %w", err)
+		}
 	}
 
 	newN.prev = n.prev
@@ -189,34 +414,41 @@ func (d *DLL[T]) InsertNodeBefore(newN, n *Node[T]) error {
 	}
 
 	d.length++
+	d.indexInsert(newN, i)
 
 	return nil
 }
 
-type Node[T any] struct {
-	next, prev *Node[T]
-	val        T
+// checkMember does a best-effort check that |n| is part of d. Nodes don't
+// know which list they belong to, so this can only catch nodes that aren't in
+// any list (or are at the ends of a different one).
+func (d *DLL[T]) checkMember(n *Node[T]) error {
+	if n.prev == nil && n != d.head {
+		return fmt.Errorf("n.prev == nil && n != d.head -- %w", ErrNotInList)
+	}
+	if n.next == nil && n != d.tail {
+		return fmt.Errorf("n.next == nil && n != d.tail -- %w", ErrNotInList)
+	}
+	return nil
 }
 
-func NewNode[T any](val T) *Node[T] { return &Node[T]{val: val} }
+// unlink removes |n| from d, which it must be a member of, and clears its
+// pointers so that it doesn't keep the rest of the list alive.
+func (d *DLL[T]) unlink(n *Node[T]) {
+	d.detach(n)
+	n.next, n.prev = nil, nil
+}
 
-func (n *Node[T]) Val() T         { return n.val }
-func (n *Node[T]) Next() *Node[T] { return n.next }
-func (n *Node[T]) Prev() *Node[T] { return n.prev }
+// detach removes |n| from d, which it must be a member of, without touching
+// n's own pointers.
+func (d *DLL[T]) detach(n *Node[T]) {
+	d.indexRemove(n)
 
-func (n *Node[T]) RemoveFrom(dll *DLL[T]) error {
-	if n.prev == nil && n != dll.head {
-		return fmt.Errorf("n.prev == nil && n != dll.head -- n is not part of this list?")
+	if n == d.head {
+		d.head = n.next
 	}
-	if n.next == nil && n != dll.tail {
-		return fmt.Errorf("n.next == nil && n != dll.tail -- n is not part of this list?")
-	}
-
-	if n == dll.head {
-		dll.head = n.next
-	}
-	if n == dll.tail {
-		dll.tail = n.prev
+	if n == d.tail {
+		d.tail = n.prev
 	}
 
 	if n.next != nil {
@@ -226,30 +458,219 @@ func (n *Node[T]) RemoveFrom(dll *DLL[T]) error {
 		n.prev.next = n.next
 	}
 
-	dll.length--
+	d.length--
+}
+
+// MoveToFront moves |n|, which must be part of d, to the head of the list.
+func (d *DLL[T]) MoveToFront(n *Node[T]) error {
+	if err := d.checkMember(n); err != nil {
+		return err
+	}
+	if n == d.head {
+		return nil
+	}
+
+	d.unlink(n)
+	d.PushNodeHead(n)
 
 	return nil
 }
 
-// TODO: do these two need refactoring?
-func (n *Node[T]) HeadIndexIn(dll *DLL[T]) (int64, error) {
-	i := int64(0)
-	for node := dll.head; node != nil; node = node.next {
-		if n == node {
-			return i, nil
+// MoveToBack moves |n|, which must be part of d, to the tail of the list.
+func (d *DLL[T]) MoveToBack(n *Node[T]) error {
+	if err := d.checkMember(n); err != nil {
+		return err
+	}
+	if n == d.tail {
+		return nil
+	}
+
+	d.unlink(n)
+	d.PushNodeTail(n)
+
+	return nil
+}
+
+// MoveAfter moves |n| so that it comes immediately after |mark|. Both must be
+// part of d.
+func (d *DLL[T]) MoveAfter(n, mark *Node[T]) error {
+	if err := d.checkMember(n); err != nil {
+		return err
+	}
+	if err := d.checkMember(mark); err != nil {
+		return err
+	}
+	if n == mark || mark.next == n {
+		return nil
+	}
+
+	d.unlink(n)
+	return d.InsertNodeAfter(n, mark)
+}
+
+// MoveBefore moves |n| so that it comes immediately before |mark|. Both must
+// be part of d.
+func (d *DLL[T]) MoveBefore(n, mark *Node[T]) error {
+	if err := d.checkMember(n); err != nil {
+		return err
+	}
+	if err := d.checkMember(mark); err != nil {
+		return err
+	}
+	if n == mark || mark.prev == n {
+		return nil
+	}
+
+	d.unlink(n)
+	return d.InsertNodeBefore(n, mark)
+}
+
+// Splice moves all the nodes of |other| onto the tail of d, leaving |other|
+// empty. This is O(1) if d isn't indexed, and O(n log n) if it is, because
+// the index has to be rebuilt.
+func (d *DLL[T]) Splice(other *DLL[T]) {
+	if other == d || other.head == nil {
+		return
+	}
+
+	if d.tail == nil {
+		d.head = other.head
+	} else {
+		d.tail.next = other.head
+		other.head.prev = d.tail
+	}
+	d.tail = other.tail
+	d.length += other.length
+
+	other.reset()
+	d.reindex()
+}
+
+// SpliceAfter moves all the nodes of |other| into d, immediately after |n|,
+// leaving |other| empty. This is O(1) if d isn't indexed, and O(n log n) if
+// it is, because the index has to be rebuilt.
+func (d *DLL[T]) SpliceAfter(other *DLL[T], n *Node[T]) error {
+	if err := d.checkMember(n); err != nil {
+		return err
+	}
+	if other == d || other.head == nil {
+		return nil
+	}
+	if n == d.tail {
+		d.Splice(other)
+		return nil
+	}
+
+	other.tail.next = n.next
+	n.next.prev = other.tail
+	n.next = other.head
+	other.head.prev = n
+	d.length += other.length
+
+	other.reset()
+	d.reindex()
+
+	return nil
+}
+
+// Reverse reverses the order of the list in place.
+func (d *DLL[T]) Reverse() {
+	for n := d.head; n != nil; n = n.prev {
+		n.next, n.prev = n.prev, n.next
+	}
+	d.head, d.tail = d.tail, d.head
+	d.reindex()
+}
+
+// Rotate rotates the list |n| places towards the head, so that the item that
+// was at index |n| becomes the new head. Negative values of |n| rotate
+// towards the tail.
+func (d *DLL[T]) Rotate(n int64) {
+	if d.length == 0 {
+		return
+	}
+	n = ((n % d.length) + d.length) % d.length
+	if n == 0 {
+		return
+	}
+
+	newHead := d.nodeAt(n)
+
+	d.tail.next = d.head
+	d.head.prev = d.tail
+	d.head, d.tail = newHead, newHead.prev
+	d.head.prev = nil
+	d.tail.next = nil
+	d.reindex()
+}
+
+// Clear removes all items from the list. This is O(n), because every node's
+// pointers are cleared, so that stale nodes can't be mistaken for members of
+// the list later.
+func (d *DLL[T]) Clear() {
+	for n := d.head; n != nil; {
+		next := n.next
+		n.next, n.prev, n.ord = nil, nil, nil
+		n = next
+	}
+	d.reset()
+}
+
+// reset makes d empty without touching its nodes, for when they've been moved
+// to another list.
+func (d *DLL[T]) reset() {
+	d.head, d.tail = nil, nil
+	d.length = 0
+	d.root = nil
+}
+
+// Find returns the first node, starting from the head, whose value satisfies
+// |pred|, or nil if there isn't one.
+func (d *DLL[T]) Find(pred func(T) bool) *Node[T] {
+	for n := d.head; n != nil; n = n.next {
+		if pred(n.val) {
+			return n
 		}
-		i++
 	}
-	return -1, fmt.Errorf("node not found in list")
+	return nil
+}
+
+type Node[T any] struct {
+	next, prev *Node[T]
+	val        T
+
+	ord *ordNode[T] // only used by indexed lists
+}
+
+func NewNode[T any](val T) *Node[T] { return &Node[T]{val: val} }
+
+func (n *Node[T]) Val() T         { return n.val }
+func (n *Node[T]) Next() *Node[T] { return n.next }
+func (n *Node[T]) Prev() *Node[T] { return n.prev }
+
+// RemoveFrom removes |n| from |dll|. n's own Next and Prev are left intact,
+// so it's safe to remove nodes while walking the list with
+// "for n := d.Head(); n != nil; n = n.Next()". n must not be passed to any of
+// dll's methods again, except to be reinserted with InsertNodeAfter and
+// friends.
+func (n *Node[T]) RemoveFrom(dll *DLL[T]) error {
+	if err := dll.checkMember(n); err != nil {
+		return err
+	}
+
+	dll.detach(n)
+
+	return nil
+}
+
+func (n *Node[T]) HeadIndexIn(dll *DLL[T]) (int64, error) {
+	return dll.IndexOf(n)
 }
 
 func (n *Node[T]) TailIndexIn(dll *DLL[T]) (int64, error) {
-	i := int64(0)
-	for node := dll.tail; node != nil; node = node.prev {
-		if n == node {
-			return i, nil
-		}
-		i++
+	i, err := dll.IndexOf(n)
+	if err != nil {
+		return -1, err
 	}
-	return -1, fmt.Errorf("node not found in list")
+	return dll.length - i - 1, nil
 }
@@ -0,0 +1,272 @@
+package doubly_linked_list
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func setupDLL(vals ...int) *DLL[int] {
+	d := NewDLL[int]()
+	for _, v := range vals {
+		d.PushTail(v)
+	}
+	return d
+}
+
+// checkList verifies that the list contains |want| when walked in both
+// directions, and that its length is consistent.
+func checkList(t *testing.T, d *DLL[int], want []int) {
+	t.Helper()
+
+	var fwd []int
+	for n := d.Head(); n != nil; n = n.Next() {
+		fwd = append(fwd, n.Val())
+	}
+
+	var bwd []int
+	for n := d.Tail(); n != nil; n = n.Prev() {
+		bwd = append([]int{n.Val()}, bwd...)
+	}
+
+	if !slices.Equal(fwd, want) {
+		t.Errorf("forwards = %v, want %v", fwd, want)
+	}
+	if !slices.Equal(bwd, want) {
+		t.Errorf("backwards = %v, want %v", bwd, want)
+	}
+	if d.Len() != int64(len(want)) {
+		t.Errorf("d.Len() = %d, want %d", d.Len(), len(want))
+	}
+}
+
+func TestPop(t *testing.T) {
+	d := setupDLL(1, 2, 3)
+
+	tail := d.Tail()
+	if v, err := d.PopTail(); err != nil || v != 3 {
+		t.Errorf("d.PopTail() = %d, %v, want 3, nil", v, err)
+	}
+	if tail.Prev() != nil || tail.Next() != nil {
+		t.Errorf("popped tail still has dangling pointers")
+	}
+	checkList(t, d, []int{1, 2})
+
+	if v, err := d.PopHead(); err != nil || v != 1 {
+		t.Errorf("d.PopHead() = %d, %v, want 1, nil", v, err)
+	}
+	checkList(t, d, []int{2})
+
+	if v, err := d.PopTail(); err != nil || v != 2 {
+		t.Errorf("d.PopTail() = %d, %v, want 2, nil", v, err)
+	}
+	checkList(t, d, nil)
+	if d.Head() != nil || d.Tail() != nil {
+		t.Errorf("empty list has head %v, tail %v, want nil", d.Head(), d.Tail())
+	}
+
+	if _, err := d.PopHead(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("d.PopHead() on empty list = %v, want ErrEmpty", err)
+	}
+	if _, err := d.PopTail(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("d.PopTail() on empty list = %v, want ErrEmpty", err)
+	}
+
+	d.PushHead(5)
+	checkList(t, d, []int{5})
+}
+
+func TestMove(t *testing.T) {
+	d := setupDLL(1, 2, 3, 4, 5)
+	three := d.Find(func(v int) bool { return v == 3 })
+
+	if err := d.MoveToFront(three); err != nil {
+		t.Fatalf("d.MoveToFront(3) = %v", err)
+	}
+	checkList(t, d, []int{3, 1, 2, 4, 5})
+
+	if err := d.MoveToBack(three); err != nil {
+		t.Fatalf("d.MoveToBack(3) = %v", err)
+	}
+	checkList(t, d, []int{1, 2, 4, 5, 3})
+
+	if err := d.MoveAfter(d.Head(), three); err != nil {
+		t.Fatalf("d.MoveAfter(1, 3) = %v", err)
+	}
+	checkList(t, d, []int{2, 4, 5, 3, 1})
+
+	if err := d.MoveBefore(d.Tail(), d.Head()); err != nil {
+		t.Fatalf("d.MoveBefore(1, 2) = %v", err)
+	}
+	checkList(t, d, []int{1, 2, 4, 5, 3})
+
+	if err := d.MoveToFront(NewNode(7)); !errors.Is(err, ErrNotInList) {
+		t.Errorf("d.MoveToFront(detached node) = %v, want ErrNotInList", err)
+	}
+}
+
+func TestSplice(t *testing.T) {
+	d := setupDLL(1, 2)
+	d.Splice(setupDLL(3, 4))
+	checkList(t, d, []int{1, 2, 3, 4})
+
+	other := setupDLL(8, 9)
+	if err := d.SpliceAfter(other, d.Head()); err != nil {
+		t.Fatalf("d.SpliceAfter() = %v", err)
+	}
+	checkList(t, d, []int{1, 8, 9, 2, 3, 4})
+	checkList(t, other, nil)
+
+	empty := NewDLL[int]()
+	empty.Splice(d)
+	checkList(t, empty, []int{1, 8, 9, 2, 3, 4})
+}
+
+func TestRemoveWhileWalking(t *testing.T) {
+	for _, indexed := range []bool{false, true} {
+		d := setupDLL(0, 1, 2, 3, 4, 5)
+		d.SetIndexed(indexed)
+
+		for n := d.Head(); n != nil; n = n.Next() {
+			if n.Val()%2 == 0 {
+				if err := n.RemoveFrom(d); err != nil {
+					t.Fatalf("n.RemoveFrom() = %v", err)
+				}
+			}
+		}
+		checkList(t, d, []int{1, 3, 5})
+	}
+}
+
+func TestClearUnlinksNodes(t *testing.T) {
+	d := setupDLL(1, 2, 3)
+	stale := d.Head().Next()
+	d.Clear()
+	d.PushTail(7)
+
+	if stale.Next() != nil || stale.Prev() != nil {
+		t.Errorf("node from cleared list still has links")
+	}
+	if err := stale.RemoveFrom(d); !errors.Is(err, ErrNotInList) {
+		t.Errorf("stale.RemoveFrom() = %v, want %v", err, ErrNotInList)
+	}
+	if err := d.MoveAfter(stale, d.Head()); !errors.Is(err, ErrNotInList) {
+		t.Errorf("d.MoveAfter(stale) = %v, want %v", err, ErrNotInList)
+	}
+	checkList(t, d, []int{7})
+}
+
+func TestReverseRotate(t *testing.T) {
+	d := setupDLL(1, 2, 3, 4, 5)
+
+	d.Reverse()
+	checkList(t, d, []int{5, 4, 3, 2, 1})
+
+	d.Rotate(2)
+	checkList(t, d, []int{3, 2, 1, 5, 4})
+
+	d.Rotate(-1)
+	checkList(t, d, []int{4, 3, 2, 1, 5})
+
+	d.Rotate(10)
+	checkList(t, d, []int{4, 3, 2, 1, 5})
+
+	d.Clear()
+	checkList(t, d, nil)
+	d.Reverse()
+	d.Rotate(3)
+	checkList(t, d, nil)
+}
+
+func TestIndexing(t *testing.T) {
+	for _, indexed := range []bool{false, true} {
+		d := setupDLL()
+		d.SetIndexed(indexed)
+
+		var want []int
+		for i := 0; i < 100; i++ {
+			switch i % 3 {
+			case 0:
+				d.PushTail(i)
+				want = append(want, i)
+			case 1:
+				d.PushHead(i)
+				want = append([]int{i}, want...)
+			case 2:
+				mid, err := d.NodeAt(d.Len() / 2)
+				if err != nil {
+					t.Fatalf("d.NodeAt(%d) = %v", d.Len()/2, err)
+				}
+				if err := d.InsertAfter(i, mid); err != nil {
+					t.Fatalf("d.InsertAfter() = %v", err)
+				}
+				want = slices.Insert(want, len(want)/2+1, i)
+			}
+		}
+
+		n, _ := d.NodeAt(40)
+		if err := n.RemoveFrom(d); err != nil {
+			t.Fatalf("n.RemoveFrom() = %v", err)
+		}
+		want = slices.Delete(want, 40, 41)
+
+		d.Rotate(7)
+		want = append(want[7:], want[:7]...)
+
+		d.Reverse()
+		slices.Reverse(want)
+
+		checkList(t, d, want)
+
+		for i, w := range want {
+			n, err := d.NodeAt(int64(i))
+			if err != nil || n.Val() != w {
+				t.Fatalf("indexed=%v: d.NodeAt(%d) = %v, %v, want %d", indexed, i, n, err, w)
+			}
+			if got, err := d.IndexOf(n); err != nil || got != int64(i) {
+				t.Errorf("indexed=%v: d.IndexOf(node %d) = %d, %v, want %d", indexed, i, got, err, i)
+			}
+			if got, err := n.TailIndexIn(d); err != nil || got != int64(len(want)-i-1) {
+				t.Errorf("indexed=%v: n.TailIndexIn(d) = %d, %v, want %d", indexed, got, err, len(want)-i-1)
+			}
+			if got, err := d.PeekTailN(int64(len(want) - i - 1)); err != nil || got != w {
+				t.Errorf("indexed=%v: d.PeekTailN(%d) = %d, %v, want %d", indexed, len(want)-i-1, got, err, w)
+			}
+		}
+
+		if _, err := d.IndexOf(NewNode(5)); !errors.Is(err, ErrNotInList) {
+			t.Errorf("indexed=%v: d.IndexOf(detached node) = %v, want ErrNotInList", indexed, err)
+		}
+		if _, err := d.NodeAt(int64(len(want))); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("indexed=%v: d.NodeAt(len) = %v, want ErrOutOfRange", indexed, err)
+		}
+	}
+}
+
+func TestIterators(t *testing.T) {
+	d := setupDLL(1, 2, 3, 4)
+
+	if got, want := slices.Collect(d.Values()), []int{1, 2, 3, 4}; !slices.Equal(got, want) {
+		t.Errorf("slices.Collect(d.Values()) = %v, want %v", got, want)
+	}
+
+	for i, v := range d.Backward() {
+		if want, _ := d.PeekHeadN(i); v != want {
+			t.Errorf("d.Backward() yielded (%d, %d), want (%d, %d)", i, v, i, want)
+		}
+	}
+
+	for n := range d.Nodes() {
+		if n.Val()%2 == 0 {
+			if err := n.RemoveFrom(d); err != nil {
+				t.Fatalf("n.RemoveFrom() = %v", err)
+			}
+		}
+	}
+	checkList(t, d, []int{1, 3})
+
+	if got, want := slices.Collect(d.Drain()), []int{1, 3}; !slices.Equal(got, want) {
+		t.Errorf("slices.Collect(d.Drain()) = %v, want %v", got, want)
+	}
+	checkList(t, d, nil)
+}
@@ -0,0 +1,175 @@
+package doubly_linked_list
+
+import "math/rand"
+
+// The indexed mode of DLL keeps an implicit treap (a randomized balanced
+// binary tree, ordered by position in the list rather than by value)
+// alongside the list, so that NodeAt and IndexOf take O(log n) time rather
+// than O(n). The treap has parent pointers so that a node's index can be
+// found by walking up from it.
+
+type ordNode[T any] struct {
+	left, right, parent *ordNode[T]
+	size                int64
+	priority            uint64
+	node                *Node[T]
+}
+
+// NewIndexedDLL creates an empty DLL in indexed mode. Indexed lists use more
+// memory and make most operations O(log n) rather than O(1), but NodeAt,
+// IndexOf and the functions built on them become O(log n) rather than O(n).
+// Splice, SpliceAfter, Reverse and Rotate rebuild the index, so they become
+// O(n log n).
+func NewIndexedDLL[T any]() *DLL[T] {
+	return &DLL[T]{indexed: true}
+}
+
+// Indexed returns whether or not d is in indexed mode.
+func (d *DLL[T]) Indexed() bool { return d.indexed }
+
+// SetIndexed turns indexed mode on or off. Turning it on takes O(n log n)
+// time.
+func (d *DLL[T]) SetIndexed(indexed bool) {
+	d.indexed = indexed
+	if indexed {
+		d.reindex()
+	} else {
+		d.root = nil
+		for n := d.head; n != nil; n = n.next {
+			n.ord = nil
+		}
+	}
+}
+
+func (d *DLL[T]) reindex() {
+	if !d.indexed {
+		return
+	}
+	d.root = nil
+	for n := d.head; n != nil; n = n.next {
+		d.root = merge(d.root, newOrdNode(n))
+	}
+	setParent(d.root, nil)
+}
+
+// indexInsert adds |n|, which has just been linked into the list at index
+// |i|, to the index.
+func (d *DLL[T]) indexInsert(n *Node[T], i int64) {
+	if !d.indexed {
+		return
+	}
+	l, r := split(d.root, i)
+	d.root = merge(merge(l, newOrdNode(n)), r)
+	setParent(d.root, nil)
+}
+
+// indexRemove removes |n| from the index. It must be called before |n| is
+// unlinked from the list.
+func (d *DLL[T]) indexRemove(n *Node[T]) {
+	if !d.indexed {
+		return
+	}
+	i, _ := rank(n.ord)
+	l, r := split(d.root, i)
+	_, r = split(r, 1)
+	d.root = merge(l, r)
+	setParent(d.root, nil)
+	n.ord = nil
+}
+
+// indexOf returns the index of |n| according to the index, and whether or not
+// |n| is actually indexed in d.
+func (d *DLL[T]) indexOf(n *Node[T]) (int64, bool) {
+	if n.ord == nil || n.ord.node != n {
+		return -1, false
+	}
+	i, top := rank(n.ord)
+	return i, top == d.root
+}
+
+func (d *DLL[T]) indexAt(i int64) *Node[T] {
+	o := d.root
+	for o != nil {
+		ls := size(o.left)
+		switch {
+		case i < ls:
+			o = o.left
+		case i == ls:
+			return o.node
+		default:
+			i -= ls + 1
+			o = o.right
+		}
+	}
+	panic("ran off the end of the index")
+}
+
+func newOrdNode[T any](n *Node[T]) *ordNode[T] {
+	o := &ordNode[T]{size: 1, priority: rand.Uint64(), node: n}
+	n.ord = o
+	return o
+}
+
+func size[T any](o *ordNode[T]) int64 {
+	if o == nil {
+		return 0
+	}
+	return o.size
+}
+
+func setParent[T any](o, p *ordNode[T]) {
+	if o != nil {
+		o.parent = p
+	}
+}
+
+func update[T any](o *ordNode[T]) {
+	o.size = size(o.left) + size(o.right) + 1
+	setParent(o.left, o)
+	setParent(o.right, o)
+}
+
+// split splits the treap rooted at |o| into the first |i| nodes and the rest.
+func split[T any](o *ordNode[T], i int64) (l, r *ordNode[T]) {
+	if o == nil {
+		return nil, nil
+	}
+	if size(o.left) < i {
+		o.right, r = split(o.right, i-size(o.left)-1)
+		update(o)
+		return o, r
+	}
+	l, o.left = split(o.left, i)
+	update(o)
+	return l, o
+}
+
+// merge concatenates two treaps.
+func merge[T any](l, r *ordNode[T]) *ordNode[T] {
+	if l == nil {
+		return r
+	}
+	if r == nil {
+		return l
+	}
+	if l.priority > r.priority {
+		l.right = merge(l.right, r)
+		update(l)
+		return l
+	}
+	r.left = merge(l, r.left)
+	update(r)
+	return r
+}
+
+// rank returns the in-order index of |o| within its treap, along with the
+// root of that treap.
+func rank[T any](o *ordNode[T]) (int64, *ordNode[T]) {
+	i := size(o.left)
+	for ; o.parent != nil; o = o.parent {
+		if o == o.parent.right {
+			i += size(o.parent.left) + 1
+		}
+	}
+	return i, o
+}
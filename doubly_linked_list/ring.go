@@ -0,0 +1,281 @@
+package doubly_linked_list
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+
+	"github.com/glennhartmann/aoclib/common"
+	"github.com/glennhartmann/aoclib/internal/containerfmt"
+)
+
+// Ring is a circular doubly linked list with a cursor. Its nodes are the same
+// Node type used by DLL, but Next() and Prev() never return nil; they wrap
+// around instead.
+type Ring[T any] struct {
+	cur    *Node[T]
+	length int64
+}
+
+func NewRing[T any]() *Ring[T] { return &Ring[T]{} }
+
+// RingFromSlice creates a Ring containing |vals|, in order, with the cursor
+// on vals[0].
+func RingFromSlice[T any](vals []T) *Ring[T] {
+	r := NewRing[T]()
+	for _, v := range vals {
+		r.InsertBefore(v)
+	}
+	return r
+}
+
+// Slice returns the values in the ring, starting from the cursor.
+func (r *Ring[T]) Slice() []T {
+	ret := make([]T, 0, r.length)
+	for _, v := range r.All() {
+		ret = append(ret, v)
+	}
+	return ret
+}
+
+// All returns an iterator over the values in the ring, going forwards once
+// round from the cursor. The indices are relative to the cursor.
+func (r *Ring[T]) All() iter.Seq2[int64, T] {
+	return func(yield func(int64, T) bool) {
+		n := r.cur
+		for i := int64(0); i < r.length; i++ {
+			if !yield(i, n.val) {
+				return
+			}
+			n = n.next
+		}
+	}
+}
+
+// Backward is like All, but goes backwards once round from the node before
+// the cursor, so that the indices count down to 0.
+func (r *Ring[T]) Backward() iter.Seq2[int64, T] {
+	return func(yield func(int64, T) bool) {
+		if r.cur == nil {
+			return
+		}
+		n := r.cur.prev
+		for i := r.length - 1; i >= 0; i-- {
+			if !yield(i, n.val) {
+				return
+			}
+			n = n.prev
+		}
+	}
+}
+
+// Values returns an iterator over the values in the ring, going forwards once
+// round from the cursor.
+func (r *Ring[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range r.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that removes values at the cursor until the ring
+// is empty or iteration stops.
+func (r *Ring[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for r.cur != nil {
+			v, err := r.Remove()
+			if err != nil {
+				panic("this really shouldn't happen")
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func (r *Ring[T]) String() string {
+	var sb strings.Builder
+	sb.WriteString("[")
+
+	for i, v := range r.All() {
+		if i > 0 {
+			sb.WriteString(" <=> ")
+		}
+		sb.WriteString(fmt.Sprintf("%v", v))
+	}
+
+	sb.WriteString(fmt.Sprintf("] (%d items)", r.length))
+	return sb.String()
+}
+
+// Details notes that the ring is circular, as printed by %+v. (String already
+// includes the size.)
+func (r *Ring[T]) Details() string {
+	return "circular, starting from the cursor"
+}
+
+// Format implements fmt.Formatter. %v prints the same as String, %+v notes
+// that the list is circular, and %#v prints a Go-syntax representation.
+func (r *Ring[T]) Format(f fmt.State, verb rune) {
+	containerfmt.Format(f, verb, r, "doubly_linked_list.Ring", r.Values())
+}
+
+func (r *Ring[T]) Len() int64       { return r.length }
+func (r *Ring[T]) Cursor() *Node[T] { return r.cur }
+
+// SetCursor moves the cursor to |n|, which must be part of r.
+func (r *Ring[T]) SetCursor(n *Node[T]) { r.cur = n }
+
+// Peek returns the value at the cursor.
+func (r *Ring[T]) Peek() (T, error) {
+	if r.cur == nil {
+		var v T
+		return v, fmt.Errorf("r.cur == nil -- ring is %w", ErrEmpty)
+	}
+	return r.cur.val, nil
+}
+
+// MustPeek is like Peek, but panics if the ring is empty.
+func (r *Ring[T]) MustPeek() T {
+	v, err := r.Peek()
+	if err != nil {
+		common.Panicf("MustPeek: %v", err)
+	}
+	return v
+}
+
+// Advance moves the cursor |n| nodes forwards (or backwards, if |n| is
+// negative). It takes O(min(k, len-k)) time, where k = n mod len.
+func (r *Ring[T]) Advance(n int64) {
+	r.cur = walkRing(r.cur, n, r.length)
+}
+
+// Retreat moves the cursor |n| nodes backwards (or forwards, if |n| is
+// negative).
+func (r *Ring[T]) Retreat(n int64) {
+	r.Advance(-n)
+}
+
+// walkRing returns the node |n| steps forward from |from|, in a ring of
+// |length| nodes, going whichever way round is shorter.
+func walkRing[T any](from *Node[T], n, length int64) *Node[T] {
+	if length == 0 {
+		return from
+	}
+
+	n = ((n % length) + length) % length
+	if n <= length/2 {
+		for i := int64(0); i < n; i++ {
+			from = from.next
+		}
+	} else {
+		for i := int64(0); i < length-n; i++ {
+			from = from.prev
+		}
+	}
+	return from
+}
+
+// InsertAfter inserts |val| immediately after the cursor, and returns the new
+// node. The cursor doesn't move, unless the ring was empty, in which case it
+// is placed on the new node.
+func (r *Ring[T]) InsertAfter(val T) *Node[T] {
+	n := NewNode(val)
+	r.insertNodeAfter(n, r.cur)
+	return n
+}
+
+// InsertBefore inserts |val| immediately before the cursor (which is also the
+// "end" of the ring, as seen by Slice), and returns the new node. The cursor
+// doesn't move, unless the ring was empty, in which case it is placed on the
+// new node.
+func (r *Ring[T]) InsertBefore(val T) *Node[T] {
+	n := NewNode(val)
+	if r.cur == nil {
+		r.insertNodeAfter(n, nil)
+	} else {
+		r.insertNodeAfter(n, r.cur.prev)
+	}
+	return n
+}
+
+func (r *Ring[T]) insertNodeAfter(n, mark *Node[T]) {
+	if mark == nil {
+		n.next, n.prev = n, n
+		r.cur = n
+	} else {
+		n.prev = mark
+		n.next = mark.next
+		mark.next.prev = n
+		mark.next = n
+	}
+	r.length++
+}
+
+// Remove removes the node at the cursor, moves the cursor to the following
+// node, and returns the removed value.
+func (r *Ring[T]) Remove() (T, error) {
+	v, err := r.Peek()
+	if err != nil {
+		return v, fmt.Errorf("r.Peek(): %w", err)
+	}
+
+	n := r.cur
+	if r.length == 1 {
+		r.cur = nil
+	} else {
+		r.cur = n.next
+	}
+	r.unlink(n)
+
+	return v, nil
+}
+
+// MustRemove is like Remove, but panics if the ring is empty.
+func (r *Ring[T]) MustRemove() T {
+	v, err := r.Remove()
+	if err != nil {
+		common.Panicf("MustRemove: %v", err)
+	}
+	return v
+}
+
+func (r *Ring[T]) unlink(n *Node[T]) {
+	n.prev.next = n.next
+	n.next.prev = n.prev
+	n.next, n.prev = nil, nil
+	r.length--
+}
+
+// Move moves |n|, which must be part of r, |steps| places forwards (or
+// backwards, if |steps| is negative) relative to the other nodes in the ring.
+// If |n| is at the cursor, the cursor moves to the following node first.
+func (r *Ring[T]) Move(n *Node[T], steps int64) {
+	if r.length <= 1 {
+		return
+	}
+
+	if n == r.cur {
+		r.cur = n.next
+	}
+
+	mark := n.prev
+	r.unlink(n)
+	r.insertNodeAfter(n, walkRing(mark, steps, r.length))
+}
+
+// Clear removes all items from the ring. This is O(n), because every node's
+// pointers are cleared.
+func (r *Ring[T]) Clear() {
+	for n, i := r.cur, int64(0); i < r.length; i++ {
+		next := n.next
+		n.next, n.prev = nil, nil
+		n = next
+	}
+	r.cur = nil
+	r.length = 0
+}
@@ -0,0 +1,90 @@
+package doubly_linked_list
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRingAdvance(t *testing.T) {
+	r := RingFromSlice([]int{0, 1, 2, 3, 4})
+
+	tests := []struct {
+		name string
+		n    int64
+		want int
+	}{
+		{name: "forwards", n: 2, want: 2},
+		{name: "backwards", n: -3, want: 4},
+		{name: "wrap", n: 11, want: 0},
+		{name: "far side", n: 4, want: 4},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r.Advance(test.n)
+			if got, _ := r.Peek(); got != test.want {
+				t.Errorf("r.Advance(%d); r.Peek() = %d, want %d", test.n, got, test.want)
+			}
+		})
+	}
+
+	r.Retreat(1)
+	if got, want := r.Slice(), []int{3, 4, 0, 1, 2}; !slices.Equal(got, want) {
+		t.Errorf("r.Slice() = %v, want %v", got, want)
+	}
+}
+
+func TestRingMarbles(t *testing.T) {
+	// AoC 2018 day 9, with 9 players and 25 marbles
+	r := RingFromSlice([]int{0})
+	scores := make([]int, 9)
+	for m := 1; m <= 25; m++ {
+		if m%23 == 0 {
+			r.Retreat(7)
+			v, err := r.Remove()
+			if err != nil {
+				t.Fatalf("r.Remove() = %v", err)
+			}
+			scores[m%9] += m + v
+			continue
+		}
+		r.Advance(1)
+		r.InsertAfter(m)
+		r.Advance(1)
+	}
+
+	if got := slices.Max(scores); got != 32 {
+		t.Errorf("high score = %d, want 32", got)
+	}
+	if got := r.Len(); got != 24 {
+		t.Errorf("r.Len() = %d, want 24", got)
+	}
+}
+
+func TestRingMove(t *testing.T) {
+	// AoC 2022 day 20 example
+	vals := []int{1, 2, -3, 3, -2, 0, 4}
+	r := NewRing[int]()
+	var nodes []*Node[int]
+	for _, v := range vals {
+		nodes = append(nodes, r.InsertBefore(v))
+	}
+
+	for _, n := range nodes {
+		r.Move(n, int64(n.Val()))
+	}
+
+	r.SetCursor(nodes[slices.Index(vals, 0)])
+	if got, want := r.Slice(), []int{0, 3, -2, 1, 2, -3, 4}; !slices.Equal(got, want) {
+		t.Errorf("r.Slice() = %v, want %v", got, want)
+	}
+
+	for r.Len() > 0 {
+		if _, err := r.Remove(); err != nil {
+			t.Fatalf("r.Remove() = %v", err)
+		}
+	}
+	if _, err := r.Remove(); err == nil {
+		t.Errorf("r.Remove() on empty ring = nil error, want error")
+	}
+}
@@ -5,6 +5,11 @@ import (
 	"github.com/glennhartmann/aoclib/grid/d8"
 )
 
+// Direction is one of the 4 orthogonal directions. Each value is equal to the
+// corresponding d8.Direction, so converting between the two is a simple type
+// conversion. As a result, the values aren't contiguous. Use All() to loop
+// over the directions, never a numeric range. Functions that are given a
+// value that isn't one of the 4 directions panic.
 type Direction int
 
 const (
@@ -14,7 +19,49 @@ const (
 	Right = Direction(d8.Right)
 )
 
+// All returns all 4 directions in clockwise order, starting from Up.
+func All() []Direction {
+	return []Direction{Up, Right, Down, Left}
+}
+
+// IsValid returns whether or not dir is one of the 4 defined directions.
+func (dir Direction) IsValid() bool {
+	return d8.Direction(dir).IsValid() && !d8.Direction(dir).IsDiagonal()
+}
+
+// Rotate returns the direction |steps| 90-degree turns clockwise from dir.
+// Negative values of |steps| rotate counter-clockwise.
+func (dir Direction) Rotate(steps int) Direction {
+	mustBeValid(dir)
+	return Direction(d8.Direction(dir).Rotate(2 * steps))
+}
+
+// TurnRight returns the direction 90 degrees clockwise from dir.
+func (dir Direction) TurnRight() Direction { return dir.Rotate(1) }
+
+// TurnLeft returns the direction 90 degrees counter-clockwise from dir.
+func (dir Direction) TurnLeft() Direction { return dir.Rotate(-1) }
+
+// D8 converts dir to the equivalent d8.Direction.
+func (dir Direction) D8() d8.Direction { return d8.Direction(dir) }
+
+// FromD8 converts a d8.Direction to the equivalent Direction. The second
+// return value is false if |dir| is diagonal (or invalid).
+func FromD8(dir d8.Direction) (Direction, bool) {
+	if !dir.IsValid() || dir.IsDiagonal() {
+		return Direction(-1), false
+	}
+	return Direction(dir), true
+}
+
+func mustBeValid(dir Direction) {
+	if !dir.IsValid() {
+		common.Panicf("invalid direction: %d", int(dir))
+	}
+}
+
 func (dir Direction) String() string {
+	mustBeValid(dir)
 	return d8.Direction(dir).String()
 }
 
@@ -29,16 +76,36 @@ func GetDirChar(dir Direction) byte {
 	case Right:
 		return '>'
 	default:
-		common.Panicf("invalid direction: %v", dir)
+		common.Panicf("invalid direction: %d", int(dir))
 	}
 	return '!'
 }
 
+// DirForChar returns the direction for an arrow character ('^', 'v', '<' or
+// '>'). It is the inverse of GetDirChar.
+func DirForChar(b byte) Direction {
+	switch b {
+	case '^':
+		return Up
+	case 'v':
+		return Down
+	case '<':
+		return Left
+	case '>':
+		return Right
+	default:
+		common.Panicf("invalid direction: %c", b)
+	}
+	return Direction(-1)
+}
+
 func GetNextCell(r, c int, dir Direction) (nr, nc int) {
+	mustBeValid(dir)
 	return d8.GetNextCell(r, c, d8.Direction(dir))
 }
 
 func OppositeDir(dir Direction) Direction {
+	mustBeValid(dir)
 	return Direction(d8.OppositeDir(d8.Direction(dir)))
 }
 
@@ -47,5 +114,51 @@ func MustFindInStringGrid(lines []string, char byte) (r, c int) {
 }
 
 func DirForUDLR(c string) Direction {
-	return Direction(d8.DirForUDLR(c))
+	return mustFromD8(d8.DirForUDLR(c), c)
+}
+
+// DirForCompass returns the direction for a compass letter ("N", "S", "E" or
+// "W"), where north is up. It panics if |c| isn't one.
+func DirForCompass(c string) Direction {
+	return mustFromD8(d8.DirForCompass(c), c)
+}
+
+// LookupDir is like d8.LookupDir, except that diagonal directions are not
+// recognized.
+func LookupDir(s string) (Direction, bool) {
+	dir, ok := d8.LookupDir(s)
+	if !ok {
+		return Direction(-1), false
+	}
+	return FromD8(dir)
+}
+
+func mustFromD8(dir d8.Direction, s string) Direction {
+	d, ok := FromD8(dir)
+	if !ok {
+		common.Panicf("invalid direction: %s", s)
+	}
+	return d
+}
+
+// Point is a (row, column) position in a grid. It's the same type as
+// d8.Point, so the two packages can be used together.
+type Point = d8.Point
+
+// GetNextPoint is the Point-based equivalent of GetNextCell.
+func GetNextPoint(p Point, dir Direction) Point {
+	mustBeValid(dir)
+	return d8.GetNextPoint(p, d8.Direction(dir))
+}
+
+// GetNextPointN returns the Point |n| steps away from p in direction |dir|.
+func GetNextPointN(p Point, dir Direction, n int) Point {
+	mustBeValid(dir)
+	return p.StepN(d8.Direction(dir), n)
+}
+
+// MustFindPointInStringGrid is the Point-based equivalent of
+// MustFindInStringGrid.
+func MustFindPointInStringGrid(lines []string, char byte) Point {
+	return d8.MustFindPointInStringGrid(lines, char)
 }
@@ -0,0 +1,93 @@
+package d4
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/glennhartmann/aoclib/grid/d8"
+)
+
+func TestTurns(t *testing.T) {
+	tests := []struct {
+		dir       Direction
+		wantLeft  Direction
+		wantRight Direction
+	}{
+		{dir: Up, wantLeft: Left, wantRight: Right},
+		{dir: Right, wantLeft: Up, wantRight: Down},
+		{dir: Down, wantLeft: Right, wantRight: Left},
+		{dir: Left, wantLeft: Down, wantRight: Up},
+	}
+
+	for _, test := range tests {
+		t.Run(test.dir.String(), func(t *testing.T) {
+			if got := test.dir.TurnLeft(); got != test.wantLeft {
+				t.Errorf("%v.TurnLeft() = %v, want %v", test.dir, got, test.wantLeft)
+			}
+			if got := test.dir.TurnRight(); got != test.wantRight {
+				t.Errorf("%v.TurnRight() = %v, want %v", test.dir, got, test.wantRight)
+			}
+			if got := test.dir.Rotate(2); got != OppositeDir(test.dir) {
+				t.Errorf("%v.Rotate(2) = %v, want %v", test.dir, got, OppositeDir(test.dir))
+			}
+		})
+	}
+}
+
+func TestConversions(t *testing.T) {
+	for _, dir := range All() {
+		if got := DirForChar(GetDirChar(dir)); got != dir {
+			t.Errorf("DirForChar(GetDirChar(%v)) = %v, want %v", dir, got, dir)
+		}
+		if got, ok := FromD8(dir.D8()); !ok || got != dir {
+			t.Errorf("FromD8(%v.D8()) = %v, %v, want %v, true", dir, got, ok, dir)
+		}
+	}
+
+	if _, ok := FromD8(d8.UpLeft); ok {
+		t.Errorf("FromD8(d8.UpLeft) = ok, want !ok")
+	}
+	if _, ok := LookupDir("NE"); ok {
+		t.Errorf("LookupDir(\"NE\") = ok, want !ok")
+	}
+	if got := DirForCompass("E"); got != Right {
+		t.Errorf("DirForCompass(\"E\") = %v, want %v", got, Right)
+	}
+}
+
+func TestInvalidDirectionPanics(t *testing.T) {
+	// Direction(1) is d8.UpRight, which a numeric loop from Up would hit.
+	bad := Direction(d8.UpRight)
+
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{name: "GetNextCell", f: func() { GetNextCell(0, 0, bad) }},
+		{name: "GetNextPoint", f: func() { GetNextPoint(Point{}, bad) }},
+		{name: "GetNextPointN", f: func() { GetNextPointN(Point{}, bad, 2) }},
+		{name: "OppositeDir", f: func() { OppositeDir(bad) }},
+		{name: "String", f: func() { _ = bad.String() }},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s(Direction(%d)) didn't panic", test.name, int(bad))
+				}
+			}()
+			test.f()
+		})
+	}
+}
+
+func TestGetDirCharInvalid(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), "invalid direction: 42") {
+			t.Errorf("GetDirChar(Direction(42)) panicked with %v, want \"invalid direction: 42\"", r)
+		}
+	}()
+	GetDirChar(Direction(42))
+}
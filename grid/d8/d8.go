@@ -2,19 +2,61 @@ package d8
 
 import "github.com/glennhartmann/aoclib/common"
 
+// Direction is one of the 8 compass directions. The values are ordered
+// clockwise, starting from Up, so that rotating is just modular arithmetic.
+//
+// Don't rely on the numeric values, or loop over numeric ranges like
+// "for dir := Up; dir <= UpLeft; dir++". Use All() instead.
 type Direction int
 
 const (
 	Up Direction = iota
+	UpRight
+	Right
+	DownRight
 	Down
+	DownLeft
 	Left
-	Right
 	UpLeft
-	UpRight
-	DownLeft
-	DownRight
+
+	numDirections = 8
 )
 
+// All returns all 8 directions in clockwise order, starting from Up.
+func All() []Direction {
+	return []Direction{Up, UpRight, Right, DownRight, Down, DownLeft, Left, UpLeft}
+}
+
+// IsValid returns whether or not dir is one of the 8 defined directions.
+func (dir Direction) IsValid() bool {
+	return dir >= Up && dir < numDirections
+}
+
+// IsDiagonal returns whether or not dir is one of the 4 diagonal directions.
+func (dir Direction) IsDiagonal() bool {
+	mustBeValid(dir)
+	return dir%2 == 1
+}
+
+// Rotate returns the direction |steps| 45-degree turns clockwise from dir.
+// Negative values of |steps| rotate counter-clockwise.
+func (dir Direction) Rotate(steps int) Direction {
+	mustBeValid(dir)
+	return Direction(((int(dir)+steps)%numDirections + numDirections) % numDirections)
+}
+
+// TurnRight returns the direction 90 degrees clockwise from dir.
+func (dir Direction) TurnRight() Direction { return dir.Rotate(2) }
+
+// TurnLeft returns the direction 90 degrees counter-clockwise from dir.
+func (dir Direction) TurnLeft() Direction { return dir.Rotate(-2) }
+
+func mustBeValid(dir Direction) {
+	if !dir.IsValid() {
+		common.Panicf("invalid direction: %d", int(dir))
+	}
+}
+
 func (dir Direction) String() string {
 	switch dir {
 	case Up:
@@ -34,7 +76,7 @@ func (dir Direction) String() string {
 	case DownRight:
 		return "down-right"
 	default:
-		common.Panicf("invalid direction: %v", dir)
+		common.Panicf("invalid direction: %d", int(dir))
 	}
 	return "INVALID"
 }
@@ -50,7 +92,7 @@ func GetNextCell(r, c int, dir Direction) (nr, nc int) {
 	case Right:
 		return r, c + 1
 	case UpLeft:
-		return r - 1, c + 1
+		return r - 1, c - 1
 	case UpRight:
 		return r - 1, c + 1
 	case DownLeft:
@@ -58,7 +100,7 @@ func GetNextCell(r, c int, dir Direction) (nr, nc int) {
 	case DownRight:
 		return r + 1, c + 1
 	default:
-		common.Panicf("invalid direction: %v", dir)
+		common.Panicf("invalid direction: %d", int(dir))
 	}
 	return -1, -1
 }
@@ -82,7 +124,7 @@ func OppositeDir(dir Direction) Direction {
 	case DownRight:
 		return UpLeft
 	default:
-		common.Panicf("invalid direction: %v", dir)
+		common.Panicf("invalid direction: %d", int(dir))
 	}
 	return Direction(-1)
 }
@@ -122,3 +164,63 @@ func DirForUDLR(c string) Direction {
 	}
 	return Direction(-1)
 }
+
+// DirForCompass returns the direction for a compass abbreviation ("N", "NE",
+// "E", etc), where north is up. It panics if |c| isn't one.
+func DirForCompass(c string) Direction {
+	switch c {
+	case "N":
+		return Up
+	case "S":
+		return Down
+	case "W":
+		return Left
+	case "E":
+		return Right
+	case "NW":
+		return UpLeft
+	case "NE":
+		return UpRight
+	case "SW":
+		return DownLeft
+	case "SE":
+		return DownRight
+	default:
+		common.Panicf("invalid direction: %s", c)
+	}
+	return Direction(-1)
+}
+
+// LookupDir returns the direction described by |s|, which may be in any of
+// the notations understood by this package: UDLR ("U", "DL"), compass ("N",
+// "SE"), arrow characters ("^", "v", "<", ">") or the names returned by
+// String ("up", "down-left"). The second return value reports whether or not
+// |s| was recognized.
+func LookupDir(s string) (Direction, bool) {
+	for _, dir := range All() {
+		if s == dir.String() {
+			return dir, true
+		}
+	}
+
+	switch s {
+	case "U", "N", "^":
+		return Up, true
+	case "D", "S", "v":
+		return Down, true
+	case "L", "W", "<":
+		return Left, true
+	case "R", "E", ">":
+		return Right, true
+	case "UL", "NW":
+		return UpLeft, true
+	case "UR", "NE":
+		return UpRight, true
+	case "DL", "SW":
+		return DownLeft, true
+	case "DR", "SE":
+		return DownRight, true
+	}
+
+	return Direction(-1), false
+}
@@ -0,0 +1,124 @@
+package d8
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestGetNextCell(t *testing.T) {
+	tests := []struct {
+		dir    Direction
+		nr, nc int
+	}{
+		{dir: Up, nr: 4, nc: 5},
+		{dir: UpRight, nr: 4, nc: 6},
+		{dir: Right, nr: 5, nc: 6},
+		{dir: DownRight, nr: 6, nc: 6},
+		{dir: Down, nr: 6, nc: 5},
+		{dir: DownLeft, nr: 6, nc: 4},
+		{dir: Left, nr: 5, nc: 4},
+		{dir: UpLeft, nr: 4, nc: 4},
+	}
+
+	for _, test := range tests {
+		t.Run(test.dir.String(), func(t *testing.T) {
+			if nr, nc := GetNextCell(5, 5, test.dir); nr != test.nr || nc != test.nc {
+				t.Errorf("GetNextCell(5, 5, %v) = %d, %d, want %d, %d", test.dir, nr, nc, test.nr, test.nc)
+			}
+		})
+	}
+}
+
+func TestInvalidDirectionPanics(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{name: "String", f: func() { _ = Direction(42).String() }},
+		{name: "GetNextCell", f: func() { GetNextCell(0, 0, Direction(42)) }},
+		{name: "OppositeDir", f: func() { OppositeDir(Direction(42)) }},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), "invalid direction: 42") {
+					t.Errorf("%s(Direction(42)) panicked with %v, want \"invalid direction: 42\"", test.name, r)
+				}
+			}()
+			test.f()
+		})
+	}
+}
+
+func TestRotate(t *testing.T) {
+	tests := []struct {
+		name  string
+		dir   Direction
+		steps int
+		want  Direction
+	}{
+		{name: "no-op", dir: Up, steps: 0, want: Up},
+		{name: "45 degrees", dir: Up, steps: 1, want: UpRight},
+		{name: "wrap clockwise", dir: UpLeft, steps: 1, want: Up},
+		{name: "wrap counter-clockwise", dir: Up, steps: -1, want: UpLeft},
+		{name: "full turn", dir: Left, steps: 8, want: Left},
+		{name: "many turns", dir: Down, steps: -19, want: UpRight},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.dir.Rotate(test.steps); got != test.want {
+				t.Errorf("%v.Rotate(%d) = %v, want %v", test.dir, test.steps, got, test.want)
+			}
+		})
+	}
+}
+
+func TestTurns(t *testing.T) {
+	for _, dir := range All() {
+		if got := dir.TurnRight().TurnRight(); got != OppositeDir(dir) {
+			t.Errorf("%v.TurnRight().TurnRight() = %v, want %v", dir, got, OppositeDir(dir))
+		}
+		if got := dir.TurnLeft().TurnRight(); got != dir {
+			t.Errorf("%v.TurnLeft().TurnRight() = %v, want %v", dir, got, dir)
+		}
+	}
+
+	var diagonals []Direction
+	for _, dir := range All() {
+		if dir.IsDiagonal() {
+			diagonals = append(diagonals, dir)
+		}
+	}
+	if want := []Direction{UpRight, DownRight, DownLeft, UpLeft}; !slices.Equal(diagonals, want) {
+		t.Errorf("diagonals = %v, want %v", diagonals, want)
+	}
+}
+
+func TestLookupDir(t *testing.T) {
+	tests := []struct {
+		s      string
+		want   Direction
+		wantOK bool
+	}{
+		{s: "U", want: Up, wantOK: true},
+		{s: "DL", want: DownLeft, wantOK: true},
+		{s: "NE", want: UpRight, wantOK: true},
+		{s: "W", want: Left, wantOK: true},
+		{s: ">", want: Right, wantOK: true},
+		{s: "down-right", want: DownRight, wantOK: true},
+		{s: "?", wantOK: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			got, ok := LookupDir(test.s)
+			if ok != test.wantOK || (ok && got != test.want) {
+				t.Errorf("LookupDir(%q) = %v, %v, want %v, %v", test.s, got, ok, test.want, test.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package d8
+
+import "github.com/glennhartmann/aoclib/common"
+
+// Point is a comparable (row, column) position in a grid, suitable for use as
+// a map key or as an element of a heap, queue or stack.
+type Point struct {
+	R, C int
+}
+
+// P creates a Point from a row and column.
+func P(r, c int) Point { return Point{r, c} }
+
+// Step returns the Point one step away from p in direction |dir|.
+func (p Point) Step(dir Direction) Point {
+	return GetNextPoint(p, dir)
+}
+
+// StepN returns the Point |n| steps away from p in direction |dir|.
+func (p Point) StepN(dir Direction, n int) Point {
+	d := p.Step(dir).Sub(p)
+	return Point{p.R + d.R*n, p.C + d.C*n}
+}
+
+// Add returns the component-wise sum of p and q.
+func (p Point) Add(q Point) Point { return Point{p.R + q.R, p.C + q.C} }
+
+// Sub returns the component-wise difference of p and q.
+func (p Point) Sub(q Point) Point { return Point{p.R - q.R, p.C - q.C} }
+
+// Manhattan returns the Manhattan (taxicab) distance between p and q.
+func (p Point) Manhattan(q Point) int {
+	return common.Abs(p.R-q.R) + common.Abs(p.C-q.C)
+}
+
+// Chebyshev returns the Chebyshev (chessboard) distance between p and q.
+func (p Point) Chebyshev(q Point) int {
+	return common.Max(common.Abs(p.R-q.R), common.Abs(p.C-q.C))
+}
+
+// Neighbors4 returns the 4 orthogonal neighbours of p.
+func (p Point) Neighbors4() []Point {
+	return []Point{p.Step(Up), p.Step(Right), p.Step(Down), p.Step(Left)}
+}
+
+// Neighbors8 returns all 8 neighbours of p, including diagonals.
+func (p Point) Neighbors8() []Point {
+	ret := make([]Point, 0, 8)
+	for _, dir := range All() {
+		ret = append(ret, p.Step(dir))
+	}
+	return ret
+}
+
+// GetNextPoint is the Point-based equivalent of GetNextCell.
+func GetNextPoint(p Point, dir Direction) Point {
+	r, c := GetNextCell(p.R, p.C, dir)
+	return Point{r, c}
+}
+
+// MustFindPointInStringGrid is the Point-based equivalent of
+// MustFindInStringGrid.
+func MustFindPointInStringGrid(lines []string, char byte) Point {
+	r, c := MustFindInStringGrid(lines, char)
+	return Point{r, c}
+}
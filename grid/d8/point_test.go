@@ -0,0 +1,50 @@
+package d8
+
+import "testing"
+
+func TestPointStep(t *testing.T) {
+	p := P(5, 5)
+
+	tests := []struct {
+		dir  Direction
+		want Point
+	}{
+		{dir: Up, want: P(4, 5)},
+		{dir: Down, want: P(6, 5)},
+		{dir: Left, want: P(5, 4)},
+		{dir: Right, want: P(5, 6)},
+		{dir: UpLeft, want: P(4, 4)},
+		{dir: UpRight, want: P(4, 6)},
+		{dir: DownLeft, want: P(6, 4)},
+		{dir: DownRight, want: P(6, 6)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.dir.String(), func(t *testing.T) {
+			if got := p.Step(test.dir); got != test.want {
+				t.Errorf("%v.Step(%v) = %v, want %v", p, test.dir, got, test.want)
+			}
+			d := test.want.Sub(p)
+			if got, want := p.StepN(test.dir, 3), P(p.R+3*d.R, p.C+3*d.C); got != want {
+				t.Errorf("%v.StepN(%v, 3) = %v, want %v", p, test.dir, got, want)
+			}
+			if got := p.Step(test.dir).Step(OppositeDir(test.dir)); got != p {
+				t.Errorf("stepping %v then back = %v, want %v", test.dir, got, p)
+			}
+		})
+	}
+}
+
+func TestPointDistances(t *testing.T) {
+	p, q := P(1, 2), P(-3, 7)
+
+	if got := p.Manhattan(q); got != 9 {
+		t.Errorf("%v.Manhattan(%v) = %d, want 9", p, q, got)
+	}
+	if got := p.Chebyshev(q); got != 5 {
+		t.Errorf("%v.Chebyshev(%v) = %d, want 5", p, q, got)
+	}
+	if got := len(p.Neighbors8()); got != 8 {
+		t.Errorf("len(%v.Neighbors8()) = %d, want 8", p, got)
+	}
+}
@@ -0,0 +1,240 @@
+// Package grid implements a generic 2D grid, along with helpers for bounds
+// checking, searching and iterating over neighbouring cells.
+package grid
+
+import (
+	"github.com/glennhartmann/aoclib/common"
+	"github.com/glennhartmann/aoclib/grid/d4"
+	"github.com/glennhartmann/aoclib/grid/d8"
+	"github.com/glennhartmann/aoclib/must"
+)
+
+// Grid is a generic 2D grid of cells, addressed by (row, column).
+type Grid[T comparable] struct {
+	cells [][]T
+}
+
+// New creates a Grid with |rows| rows and |cols| columns, with every cell set
+// to the zero value of T.
+func New[T comparable](rows, cols int) *Grid[T] {
+	cells := make([][]T, rows)
+	for r := range cells {
+		cells[r] = make([]T, cols)
+	}
+	return &Grid[T]{cells}
+}
+
+// NewFilled creates a Grid with |rows| rows and |cols| columns, with every
+// cell set to |v|.
+func NewFilled[T comparable](rows, cols int, v T) *Grid[T] {
+	g := New[T](rows, cols)
+	g.Fill(v)
+	return g
+}
+
+// FromSlices creates a Grid backed by |cells|. The slices are not copied, so
+// changes made through the Grid are visible to the caller and vice-versa. All
+// rows are expected to have the same length.
+func FromSlices[T comparable](cells [][]T) *Grid[T] {
+	return &Grid[T]{cells}
+}
+
+// FromStrings creates a byte Grid from a slice of lines.
+func FromStrings(lines []string) *Grid[byte] {
+	return FromSlices(common.StringSliceToByteSlice2(lines))
+}
+
+// FromInput creates a byte Grid from the full contents of the current
+// must.Input() Source (stdin by default).
+func FromInput() *Grid[byte] {
+	return FromSlices(must.GetFullInputAsBytes())
+}
+
+// Strings converts a byte Grid back into a slice of lines.
+func Strings(g *Grid[byte]) []string {
+	return common.ByteSlice2ToStringSlice(g.cells)
+}
+
+// Raw returns the underlying slices of the Grid. They are not copied.
+func (g *Grid[T]) Raw() [][]T { return g.cells }
+
+// Rows returns the number of rows in the Grid.
+func (g *Grid[T]) Rows() int { return len(g.cells) }
+
+// Cols returns the number of columns in the Grid.
+func (g *Grid[T]) Cols() int {
+	if len(g.cells) == 0 {
+		return 0
+	}
+	return len(g.cells[0])
+}
+
+// InBounds returns whether or not (r, c) is a valid cell in the Grid.
+func (g *Grid[T]) InBounds(r, c int) bool {
+	return r >= 0 && r < g.Rows() && c >= 0 && c < len(g.cells[r])
+}
+
+// At returns the value of the cell at (r, c). It panics if (r, c) is out of
+// bounds.
+func (g *Grid[T]) At(r, c int) T {
+	if !g.InBounds(r, c) {
+		common.Panicf("(%d, %d) out of bounds for %dx%d grid", r, c, g.Rows(), g.Cols())
+	}
+	return g.cells[r][c]
+}
+
+// Get returns the value of the cell at (r, c), and whether or not (r, c) is in
+// bounds. If it's not, the zero value of T is returned.
+func (g *Grid[T]) Get(r, c int) (T, bool) {
+	if !g.InBounds(r, c) {
+		var zero T
+		return zero, false
+	}
+	return g.cells[r][c], true
+}
+
+// Set sets the value of the cell at (r, c). It panics if (r, c) is out of
+// bounds.
+func (g *Grid[T]) Set(r, c int, v T) {
+	if !g.InBounds(r, c) {
+		common.Panicf("(%d, %d) out of bounds for %dx%d grid", r, c, g.Rows(), g.Cols())
+	}
+	g.cells[r][c] = v
+}
+
+// Fill sets every cell in the Grid to |v|.
+func (g *Grid[T]) Fill(v T) {
+	for r := range g.cells {
+		for c := range g.cells[r] {
+			g.cells[r][c] = v
+		}
+	}
+}
+
+// Clone returns a deep copy of the Grid.
+func (g *Grid[T]) Clone() *Grid[T] {
+	cells := make([][]T, len(g.cells))
+	for r := range g.cells {
+		cells[r] = append([]T(nil), g.cells[r]...)
+	}
+	return &Grid[T]{cells}
+}
+
+// ForEach calls |f| for every cell in the Grid, in row-major order.
+func (g *Grid[T]) ForEach(f func(r, c int, v T)) {
+	for r := range g.cells {
+		for c := range g.cells[r] {
+			f(r, c, g.cells[r][c])
+		}
+	}
+}
+
+// Find returns the location of the first cell (in row-major order) equal to
+// |v|, and whether or not one was found.
+func (g *Grid[T]) Find(v T) (r, c int, ok bool) {
+	for r := range g.cells {
+		for c := range g.cells[r] {
+			if g.cells[r][c] == v {
+				return r, c, true
+			}
+		}
+	}
+	return -1, -1, false
+}
+
+// MustFind returns the location of the first cell (in row-major order) equal
+// to |v|. It panics if there isn't one.
+func (g *Grid[T]) MustFind(v T) (r, c int) {
+	r, c, ok := g.Find(v)
+	if !ok {
+		common.Panicf("%v not found", v)
+	}
+	return r, c
+}
+
+// FindAll returns the locations of every cell equal to |v|, in row-major
+// order.
+func (g *Grid[T]) FindAll(v T) []d8.Point {
+	var ret []d8.Point
+	g.ForEach(func(r, c int, e T) {
+		if e == v {
+			ret = append(ret, d8.Point{R: r, C: c})
+		}
+	})
+	return ret
+}
+
+// Count returns the number of cells equal to |v|.
+func (g *Grid[T]) Count(v T) int {
+	return len(g.FindAll(v))
+}
+
+// ForEachNeighbor4 calls |f| for each in-bounds orthogonal neighbour of
+// (r, c).
+func (g *Grid[T]) ForEachNeighbor4(r, c int, f func(nr, nc int, dir d4.Direction, v T)) {
+	for _, dir := range d4.All() {
+		nr, nc := d4.GetNextCell(r, c, dir)
+		if g.InBounds(nr, nc) {
+			f(nr, nc, dir, g.cells[nr][nc])
+		}
+	}
+}
+
+// ForEachNeighbor8 calls |f| for each in-bounds neighbour of (r, c),
+// including diagonals.
+func (g *Grid[T]) ForEachNeighbor8(r, c int, f func(nr, nc int, dir d8.Direction, v T)) {
+	for _, dir := range d8.All() {
+		nr, nc := d8.GetNextCell(r, c, dir)
+		if g.InBounds(nr, nc) {
+			f(nr, nc, dir, g.cells[nr][nc])
+		}
+	}
+}
+
+// WithSentinel returns a copy of the Grid surrounded by a one-cell border of
+// |s| on every side. Using a sentinel border lets callers look at the
+// neighbours of any non-border cell without bounds checks. Note that the
+// coordinates of every original cell are shifted by (1, 1). An empty Grid
+// becomes a 2x2 Grid of |s|.
+func (g *Grid[T]) WithSentinel(s T) *Grid[T] {
+	if g.Rows() == 0 {
+		return NewFilled(2, 2, s)
+	}
+	return &Grid[T]{common.AddSentinal2(g.Clone().cells, s)}
+}
+
+// InBoundsPoint is the Point-based equivalent of InBounds.
+func (g *Grid[T]) InBoundsPoint(p d8.Point) bool { return g.InBounds(p.R, p.C) }
+
+// AtPoint is the Point-based equivalent of At.
+func (g *Grid[T]) AtPoint(p d8.Point) T { return g.At(p.R, p.C) }
+
+// GetPoint is the Point-based equivalent of Get.
+func (g *Grid[T]) GetPoint(p d8.Point) (T, bool) { return g.Get(p.R, p.C) }
+
+// SetPoint is the Point-based equivalent of Set.
+func (g *Grid[T]) SetPoint(p d8.Point, v T) { g.Set(p.R, p.C, v) }
+
+// MustFindPoint is the Point-based equivalent of MustFind.
+func (g *Grid[T]) MustFindPoint(v T) d8.Point {
+	r, c := g.MustFind(v)
+	return d8.Point{R: r, C: c}
+}
+
+// Neighbors4 returns the in-bounds orthogonal neighbours of |p|.
+func (g *Grid[T]) Neighbors4(p d8.Point) []d8.Point {
+	var ret []d8.Point
+	g.ForEachNeighbor4(p.R, p.C, func(nr, nc int, _ d4.Direction, _ T) {
+		ret = append(ret, d8.Point{R: nr, C: nc})
+	})
+	return ret
+}
+
+// Neighbors8 returns the in-bounds neighbours of |p|, including diagonals.
+func (g *Grid[T]) Neighbors8(p d8.Point) []d8.Point {
+	var ret []d8.Point
+	g.ForEachNeighbor8(p.R, p.C, func(nr, nc int, _ d8.Direction, _ T) {
+		ret = append(ret, d8.Point{R: nr, C: nc})
+	})
+	return ret
+}
@@ -0,0 +1,132 @@
+package grid
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/glennhartmann/aoclib/grid/d4"
+	"github.com/glennhartmann/aoclib/grid/d8"
+)
+
+func setupGrid() *Grid[byte] {
+	return FromStrings([]string{
+		"#.#",
+		".S.",
+		"#.#",
+	})
+}
+
+func TestGridBasics(t *testing.T) {
+	g := setupGrid()
+
+	if g.Rows() != 3 || g.Cols() != 3 {
+		t.Errorf("g.Rows(), g.Cols() = %d, %d, want 3, 3", g.Rows(), g.Cols())
+	}
+
+	tests := []struct {
+		name string
+		r, c int
+		want bool
+	}{
+		{name: "corner", r: 0, c: 0, want: true},
+		{name: "centre", r: 1, c: 1, want: true},
+		{name: "negative row", r: -1, c: 1, want: false},
+		{name: "negative col", r: 1, c: -1, want: false},
+		{name: "row too big", r: 3, c: 1, want: false},
+		{name: "col too big", r: 1, c: 3, want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := g.InBounds(test.r, test.c); got != test.want {
+				t.Errorf("g.InBounds(%d, %d) = %v, want %v", test.r, test.c, got, test.want)
+			}
+		})
+	}
+
+	g.Set(0, 1, 'x')
+	if got := g.At(0, 1); got != 'x' {
+		t.Errorf("g.At(0, 1) = %c, want x", got)
+	}
+}
+
+func TestGridFind(t *testing.T) {
+	g := setupGrid()
+
+	r, c := g.MustFind('S')
+	if r != 1 || c != 1 {
+		t.Errorf("g.MustFind('S') = %d, %d, want 1, 1", r, c)
+	}
+
+	want := []d8.Point{d8.P(0, 0), d8.P(0, 2), d8.P(2, 0), d8.P(2, 2)}
+	if got := g.FindAll('#'); !slices.Equal(got, want) {
+		t.Errorf("g.FindAll('#') = %v, want %v", got, want)
+	}
+
+	if _, _, ok := g.Find('?'); ok {
+		t.Errorf("g.Find('?') = ok, want not found")
+	}
+}
+
+func TestGridNeighbors(t *testing.T) {
+	g := setupGrid()
+
+	var got4 []d4.Direction
+	g.ForEachNeighbor4(0, 0, func(nr, nc int, dir d4.Direction, v byte) {
+		got4 = append(got4, dir)
+	})
+	if want := []d4.Direction{d4.Right, d4.Down}; !slices.Equal(got4, want) {
+		t.Errorf("g.ForEachNeighbor4(0, 0) directions = %v, want %v", got4, want)
+	}
+
+	count := 0
+	g.ForEachNeighbor8(1, 1, func(nr, nc int, dir d8.Direction, v byte) {
+		if wr, wc := d8.GetNextCell(1, 1, dir); wr != nr || wc != nc {
+			t.Errorf("neighbour in direction %v = (%d, %d), want (%d, %d)", dir, nr, nc, wr, wc)
+		}
+		count++
+	})
+	if count != 8 {
+		t.Errorf("g.ForEachNeighbor8(1, 1) visited %d cells, want 8", count)
+	}
+}
+
+func TestGridWithSentinel(t *testing.T) {
+	g := setupGrid().WithSentinel('~')
+
+	want := []string{
+		"~~~~~",
+		"~#.#~",
+		"~.S.~",
+		"~#.#~",
+		"~~~~~",
+	}
+	if got := Strings(g); !slices.Equal(got, want) {
+		t.Errorf("Strings(g.WithSentinel('~')) = %q, want %q", got, want)
+	}
+}
+
+func TestGridWithSentinelEmpty(t *testing.T) {
+	g := New[byte](0, 0).WithSentinel('~')
+	if want := []string{"~~", "~~"}; !slices.Equal(Strings(g), want) {
+		t.Errorf("Strings(empty.WithSentinel('~')) = %q, want %q", Strings(g), want)
+	}
+}
+
+func TestGridPoints(t *testing.T) {
+	g := setupGrid()
+
+	p := g.MustFindPoint('S')
+	if want := d8.P(1, 1); p != want {
+		t.Errorf("g.MustFindPoint('S') = %v, want %v", p, want)
+	}
+
+	want := []d8.Point{d8.P(0, 1), d8.P(1, 2), d8.P(2, 1), d8.P(1, 0)}
+	if got := g.Neighbors4(p); !slices.Equal(got, want) {
+		t.Errorf("g.Neighbors4(%v) = %v, want %v", p, got, want)
+	}
+
+	if got := g.Neighbors8(d8.P(0, 0)); len(got) != 3 {
+		t.Errorf("g.Neighbors8({0, 0}) = %v, want 3 neighbours", got)
+	}
+}
@@ -1,23 +1,48 @@
+// Package heap implements a fully generic binary heap. It is a type-safe
+// wrapper around container/heap.
 package heap
 
 import (
 	"container/heap"
+	"iter"
 
+	"github.com/glennhartmann/aoclib/common"
 	"golang.org/x/exp/constraints"
 )
 
-type Heap[T constraints.Ordered] struct {
+// Heap is a generic binary heap. The element at the top of the heap is the one
+// that is "least" according to the heap's comparison function.
+type Heap[T any] struct {
 	hi *heapInternal[T]
 }
 
+// Init creates an empty min-heap (if |min| is true) or max-heap (otherwise)
+// of Ordered values.
 func Init[T constraints.Ordered](min bool) Heap[T] {
 	return InitN[T](min, 0)
 }
 
+// InitN is like Init, but with |size| elements worth of preallocated memory.
 func InitN[T constraints.Ordered](min bool, size int) Heap[T] {
+	if min {
+		return NewFuncN(size, func(a, b T) bool { return a < b })
+	}
+	return NewFuncN(size, func(a, b T) bool { return a > b })
+}
+
+// NewFunc creates an empty heap of arbitrary values, ordered by |less|. The
+// top of the heap is always an element e such that less(x, e) is false for
+// every other element x.
+func NewFunc[T any](less func(a, b T) bool) Heap[T] {
+	return NewFuncN(0, less)
+}
+
+// NewFuncN is like NewFunc, but with |size| elements worth of preallocated
+// memory.
+func NewFuncN[T any](size int, less func(a, b T) bool) Heap[T] {
 	h := Heap[T]{&heapInternal[T]{
 		impl: make([]T, 0, size),
-		min:  min,
+		less: less,
 	}}
 	heap.Init(h.hi)
 	return h
@@ -28,17 +53,61 @@ func (h Heap[T]) Pop() T         { return heap.Pop(h.hi).(T) }
 func (h Heap[T]) Push(e T)       { heap.Push(h.hi, e) }
 func (h Heap[T]) Remove(i int) T { return heap.Remove(h.hi, i).(T) }
 
-type heapInternal[T constraints.Ordered] struct {
+// Len returns the number of elements in the heap.
+func (h Heap[T]) Len() int { return h.hi.Len() }
+
+// Empty returns whether or not the heap is empty.
+func (h Heap[T]) Empty() bool { return h.Len() == 0 }
+
+// Peek returns the element at the top of the heap without removing it. It
+// panics if the heap is empty.
+func (h Heap[T]) Peek() T {
+	if h.Empty() {
+		common.Panicf("heap is empty")
+	}
+	return h.hi.impl[0]
+}
+
+// PushN pushes N elements onto the heap.
+func (h Heap[T]) PushN(e ...T) {
+	for _, v := range e {
+		h.Push(v)
+	}
+}
+
+// All returns an iterator over the elements of the heap, in no particular
+// order. The heap must not be modified during iteration.
+func (h Heap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range h.hi.impl {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that pops elements off the heap, in order, until
+// the heap is empty or iteration stops. Elements pushed during iteration will
+// also be popped.
+func (h Heap[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for !h.Empty() {
+			if !yield(h.Pop()) {
+				return
+			}
+		}
+	}
+}
+
+type heapInternal[T any] struct {
 	impl []T
-	min  bool
+	less func(a, b T) bool
 }
 
 func (hi heapInternal[T]) Len() int { return len(hi.impl) }
 func (hi heapInternal[T]) Less(i, j int) bool {
-	if hi.min {
-		return hi.impl[i] < hi.impl[j]
-	}
-	return hi.impl[i] > hi.impl[j]
+	return hi.less(hi.impl[i], hi.impl[j])
 }
 func (hi heapInternal[T]) Swap(i, j int) {
 	hi.impl[i], hi.impl[j] = hi.impl[j], hi.impl[i]
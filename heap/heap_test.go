@@ -0,0 +1,67 @@
+package heap
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestInit(t *testing.T) {
+	tests := []struct {
+		name string
+		min  bool
+		want []int
+	}{
+		{name: "min", min: true, want: []int{1, 2, 3, 5, 8}},
+		{name: "max", min: false, want: []int{8, 5, 3, 2, 1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := Init[int](test.min)
+			h.PushN(3, 8, 1, 5, 2)
+
+			if got := h.Len(); got != 5 {
+				t.Errorf("h.Len() = %d, want 5", got)
+			}
+			if got := h.Peek(); got != test.want[0] {
+				t.Errorf("h.Peek() = %d, want %d", got, test.want[0])
+			}
+			if got := slices.Collect(h.Drain()); !slices.Equal(got, test.want) {
+				t.Errorf("slices.Collect(h.Drain()) = %v, want %v", got, test.want)
+			}
+			if !h.Empty() {
+				t.Errorf("h.Empty() = false after draining")
+			}
+		})
+	}
+}
+
+func TestNewFunc(t *testing.T) {
+	type item struct {
+		cost int
+		name string
+	}
+
+	h := NewFunc(func(a, b item) bool { return a.cost < b.cost })
+	h.PushN(item{5, "e"}, item{1, "a"}, item{3, "c"})
+
+	if got := h.Pop(); got.name != "a" {
+		t.Errorf("h.Pop() = %v, want a", got)
+	}
+
+	h.Push(item{2, "b"})
+
+	var got []string
+	for v := range h.Drain() {
+		got = append(got, v.name)
+		if v.name == "c" {
+			break
+		}
+	}
+	if want := []string{"b", "c"}; !slices.Equal(got, want) {
+		t.Errorf("partial drain = %v, want %v", got, want)
+	}
+	if h.Len() != 1 {
+		t.Errorf("h.Len() = %d after partial drain, want 1", h.Len())
+	}
+}
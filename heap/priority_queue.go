@@ -0,0 +1,165 @@
+package heap
+
+import (
+	"container/heap"
+	"iter"
+
+	"github.com/glennhartmann/aoclib/common"
+	"golang.org/x/exp/constraints"
+)
+
+// PriorityQueue is a priority queue of unique keys, each with an associated
+// priority. Unlike Heap, it keeps track of where each key lives, so keys can
+// have their priority updated or be removed efficiently.
+type PriorityQueue[K comparable, P any] struct {
+	pqi *pqInternal[K, P]
+}
+
+// NewPriorityQueue creates an empty PriorityQueue in which lower priorities
+// are popped first.
+func NewPriorityQueue[K comparable, P constraints.Ordered]() PriorityQueue[K, P] {
+	return NewPriorityQueueFunc[K](func(a, b P) bool { return a < b })
+}
+
+// NewPriorityQueueFunc creates an empty PriorityQueue ordered by |less|; the
+// key with the "least" priority is popped first.
+func NewPriorityQueueFunc[K comparable, P any](less func(a, b P) bool) PriorityQueue[K, P] {
+	return PriorityQueue[K, P]{&pqInternal[K, P]{
+		index: make(map[K]int),
+		less:  less,
+	}}
+}
+
+// Len returns the number of keys in the queue.
+func (pq PriorityQueue[K, P]) Len() int { return pq.pqi.Len() }
+
+// Empty returns whether or not the queue is empty.
+func (pq PriorityQueue[K, P]) Empty() bool { return pq.Len() == 0 }
+
+// Contains returns whether or not |key| is in the queue.
+func (pq PriorityQueue[K, P]) Contains(key K) bool {
+	_, ok := pq.pqi.index[key]
+	return ok
+}
+
+// Priority returns the priority of |key|, and whether or not it is in the
+// queue.
+func (pq PriorityQueue[K, P]) Priority(key K) (P, bool) {
+	i, ok := pq.pqi.index[key]
+	if !ok {
+		var r P
+		return r, false
+	}
+	return pq.pqi.entries[i].priority, true
+}
+
+// Update sets the priority of |key|, adding it to the queue if it isn't
+// already there.
+func (pq PriorityQueue[K, P]) Update(key K, priority P) {
+	if i, ok := pq.pqi.index[key]; ok {
+		pq.pqi.entries[i].priority = priority
+		heap.Fix(pq.pqi, i)
+		return
+	}
+	heap.Push(pq.pqi, pqEntry[K, P]{key, priority})
+}
+
+// DecreaseKey is like Update, except that the priority of a key already in the
+// queue is only changed if |priority| is "less" than its current priority. It
+// returns whether or not the queue was modified.
+func (pq PriorityQueue[K, P]) DecreaseKey(key K, priority P) bool {
+	if old, ok := pq.Priority(key); ok && !pq.pqi.less(priority, old) {
+		return false
+	}
+	pq.Update(key, priority)
+	return true
+}
+
+// Remove removes |key| from the queue, returning its priority and whether or
+// not it was in the queue.
+func (pq PriorityQueue[K, P]) Remove(key K) (P, bool) {
+	i, ok := pq.pqi.index[key]
+	if !ok {
+		var r P
+		return r, false
+	}
+	return heap.Remove(pq.pqi, i).(pqEntry[K, P]).priority, true
+}
+
+// PeekMin returns the key with the least priority, along with its priority,
+// without removing it. It panics if the queue is empty.
+func (pq PriorityQueue[K, P]) PeekMin() (K, P) {
+	if pq.Empty() {
+		common.Panicf("priority queue is empty")
+	}
+	e := pq.pqi.entries[0]
+	return e.key, e.priority
+}
+
+// PopMin removes and returns the key with the least priority, along with its
+// priority. It panics if the queue is empty.
+func (pq PriorityQueue[K, P]) PopMin() (K, P) {
+	if pq.Empty() {
+		common.Panicf("priority queue is empty")
+	}
+	e := heap.Pop(pq.pqi).(pqEntry[K, P])
+	return e.key, e.priority
+}
+
+// All returns an iterator over the keys and priorities in the queue, in no
+// particular order. The queue must not be modified during iteration.
+func (pq PriorityQueue[K, P]) All() iter.Seq2[K, P] {
+	return func(yield func(K, P) bool) {
+		for _, e := range pq.pqi.entries {
+			if !yield(e.key, e.priority) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that pops keys and priorities off the queue, in
+// order, until the queue is empty or iteration stops.
+func (pq PriorityQueue[K, P]) Drain() iter.Seq2[K, P] {
+	return func(yield func(K, P) bool) {
+		for !pq.Empty() {
+			if !yield(pq.PopMin()) {
+				return
+			}
+		}
+	}
+}
+
+type pqEntry[K comparable, P any] struct {
+	key      K
+	priority P
+}
+
+type pqInternal[K comparable, P any] struct {
+	entries []pqEntry[K, P]
+	index   map[K]int
+	less    func(a, b P) bool
+}
+
+func (pqi *pqInternal[K, P]) Len() int { return len(pqi.entries) }
+func (pqi *pqInternal[K, P]) Less(i, j int) bool {
+	return pqi.less(pqi.entries[i].priority, pqi.entries[j].priority)
+}
+func (pqi *pqInternal[K, P]) Swap(i, j int) {
+	pqi.entries[i], pqi.entries[j] = pqi.entries[j], pqi.entries[i]
+	pqi.index[pqi.entries[i].key] = i
+	pqi.index[pqi.entries[j].key] = j
+}
+
+func (pqi *pqInternal[K, P]) Push(x any) {
+	e := x.(pqEntry[K, P])
+	pqi.index[e.key] = len(pqi.entries)
+	pqi.entries = append(pqi.entries, e)
+}
+
+func (pqi *pqInternal[K, P]) Pop() any {
+	e := pqi.entries[len(pqi.entries)-1]
+	pqi.entries = pqi.entries[:len(pqi.entries)-1]
+	delete(pqi.index, e.key)
+	return e
+}
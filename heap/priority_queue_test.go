@@ -0,0 +1,51 @@
+package heap
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPriorityQueue(t *testing.T) {
+	pq := NewPriorityQueue[string, int]()
+	pq.Update("a", 5)
+	pq.Update("b", 3)
+	pq.Update("c", 8)
+	pq.Update("d", 1)
+
+	if !pq.Contains("c") || pq.Contains("z") {
+		t.Errorf("pq.Contains(c), pq.Contains(z) = %v, %v, want true, false", pq.Contains("c"), pq.Contains("z"))
+	}
+
+	pq.Update("c", 0)
+	if pq.DecreaseKey("b", 4) {
+		t.Errorf("pq.DecreaseKey(b, 4) = true, want false")
+	}
+	if !pq.DecreaseKey("a", 2) {
+		t.Errorf("pq.DecreaseKey(a, 2) = false, want true")
+	}
+
+	if p, ok := pq.Remove("d"); !ok || p != 1 {
+		t.Errorf("pq.Remove(d) = %d, %v, want 1, true", p, ok)
+	}
+	if _, ok := pq.Remove("d"); ok {
+		t.Errorf("pq.Remove(d) succeeded twice")
+	}
+
+	if k, p := pq.PeekMin(); k != "c" || p != 0 {
+		t.Errorf("pq.PeekMin() = %s, %d, want c, 0", k, p)
+	}
+
+	var keys []string
+	var prios []int
+	for !pq.Empty() {
+		k, p := pq.PopMin()
+		keys = append(keys, k)
+		prios = append(prios, p)
+	}
+	if want := []string{"c", "a", "b"}; !slices.Equal(keys, want) {
+		t.Errorf("popped keys = %v, want %v", keys, want)
+	}
+	if want := []int{0, 2, 3}; !slices.Equal(prios, want) {
+		t.Errorf("popped priorities = %v, want %v", prios, want)
+	}
+}
@@ -0,0 +1,56 @@
+// Package containerfmt implements fmt.Formatter support shared by the
+// container packages, so that they all print consistently.
+package containerfmt
+
+import (
+	"fmt"
+	"iter"
+	"reflect"
+	"strings"
+)
+
+// Container is the information a container needs to provide in order to be
+// formatted.
+type Container interface {
+	// String returns the plain (%v) representation of the container.
+	String() string
+
+	// Details returns extra information (such as size and capacity) to be
+	// appended for %+v.
+	Details() string
+}
+
+// Format implements fmt.Formatter for a container |c|. %v and %s print
+// c.String(), %+v additionally prints c.Details(), and %#v prints a Go-syntax
+// representation, using |typeName| (e.g. "stack.Stack") and |vals|.
+func Format[T any](f fmt.State, verb rune, c Container, typeName string, vals iter.Seq[T]) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		fmt.Fprint(f, GoString(typeName, vals))
+	case verb == 'v' && f.Flag('+'):
+		fmt.Fprintf(f, "%s (%s)", c.String(), c.Details())
+	case verb == 'v' || verb == 's':
+		fmt.Fprint(f, c.String())
+	default:
+		fmt.Fprintf(f, "%%!%c(%s)", verb, c.String())
+	}
+}
+
+// GoString returns a Go-syntax representation of a container, like
+// `stack.Stack[int]{1, 2, 3}`.
+func GoString[T any](typeName string, vals iter.Seq[T]) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s[%s]{", typeName, reflect.TypeFor[T]()))
+
+	first := true
+	for v := range vals {
+		if !first {
+			sb.WriteString(", ")
+		}
+		first = false
+		sb.WriteString(fmt.Sprintf("%#v", v))
+	}
+
+	sb.WriteString("}")
+	return sb.String()
+}
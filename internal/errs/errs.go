@@ -0,0 +1,18 @@
+// Package errs defines the sentinel errors shared by the container packages,
+// so that errors.Is works the same way no matter which container returned the
+// error. Each public package re-exports the ones it uses.
+package errs
+
+import "errors"
+
+var (
+	// ErrEmpty is returned when popping or peeking from an empty container.
+	ErrEmpty = errors.New("empty")
+
+	// ErrNotInList is returned when a node isn't part of the list it's being
+	// used with.
+	ErrNotInList = errors.New("node not in list")
+
+	// ErrOutOfRange is returned when an index or count is out of range.
+	ErrOutOfRange = errors.New("out of range")
+)
@@ -0,0 +1,139 @@
+// Package ringbuffer implements a growable circular buffer, which supports
+// amortized O(1) pushes and pops at both ends.
+package ringbuffer
+
+import "fmt"
+
+// minCap is the capacity below which a RingBuffer never automatically
+// shrinks, so that a buffer that's repeatedly filled and emptied by a few
+// elements (as in a DFS loop) keeps reusing the same memory.
+const minCap = 16
+
+// RingBuffer is a growable circular buffer. Its capacity doubles when it's
+// full, and halves when it's less than a quarter full (but never below
+// minCap or the reserved capacity), so memory use stays proportional to the
+// number of live elements.
+type RingBuffer[T any] struct {
+	buf      []T
+	head     int
+	size     int
+	reserved int
+}
+
+// New creates an empty RingBuffer with |capacity| elements worth of reserved
+// memory.
+func New[T any](capacity int) *RingBuffer[T] {
+	return &RingBuffer[T]{buf: make([]T, capacity), reserved: capacity}
+}
+
+// Len returns the number of elements in the buffer.
+func (rb *RingBuffer[T]) Len() int { return rb.size }
+
+// Cap returns the number of elements the buffer can hold without growing.
+func (rb *RingBuffer[T]) Cap() int { return len(rb.buf) }
+
+// At returns the element at index |i|, where 0 is the front of the buffer. It
+// panics if |i| is out of range.
+func (rb *RingBuffer[T]) At(i int) T {
+	return rb.buf[rb.index(i)]
+}
+
+// Set sets the element at index |i|, where 0 is the front of the buffer. It
+// panics if |i| is out of range.
+func (rb *RingBuffer[T]) Set(i int, v T) {
+	rb.buf[rb.index(i)] = v
+}
+
+func (rb *RingBuffer[T]) index(i int) int {
+	if i < 0 || i >= rb.size {
+		panic(fmt.Sprintf("index %d out of range for ring buffer of length %d", i, rb.size))
+	}
+	return (rb.head + i) % len(rb.buf)
+}
+
+// PushBack adds |v| to the back of the buffer.
+func (rb *RingBuffer[T]) PushBack(v T) {
+	rb.grow()
+	rb.buf[(rb.head+rb.size)%len(rb.buf)] = v
+	rb.size++
+}
+
+// PushFront adds |v| to the front of the buffer.
+func (rb *RingBuffer[T]) PushFront(v T) {
+	rb.grow()
+	rb.head = (rb.head - 1 + len(rb.buf)) % len(rb.buf)
+	rb.buf[rb.head] = v
+	rb.size++
+}
+
+// PopFront removes and returns the element at the front of the buffer. It
+// panics if the buffer is empty.
+func (rb *RingBuffer[T]) PopFront() T {
+	i := rb.index(0)
+	v := rb.buf[i]
+
+	var zero T
+	rb.buf[i] = zero
+	rb.head = (rb.head + 1) % len(rb.buf)
+	rb.size--
+
+	rb.shrink()
+	return v
+}
+
+// PopBack removes and returns the element at the back of the buffer. It panics
+// if the buffer is empty.
+func (rb *RingBuffer[T]) PopBack() T {
+	i := rb.index(rb.size - 1)
+	v := rb.buf[i]
+
+	var zero T
+	rb.buf[i] = zero
+	rb.size--
+
+	rb.shrink()
+	return v
+}
+
+// Reserve ensures that the buffer can hold at least |n| elements without
+// growing, and that it won't automatically shrink below that.
+func (rb *RingBuffer[T]) Reserve(n int) {
+	rb.reserved = max(rb.reserved, n)
+	if n > len(rb.buf) {
+		rb.resize(n)
+	}
+}
+
+// Shrink releases any reserved capacity, and reduces the capacity of the
+// buffer to exactly the number of elements it holds.
+func (rb *RingBuffer[T]) Shrink() {
+	rb.reserved = 0
+	rb.resize(rb.size)
+}
+
+func (rb *RingBuffer[T]) grow() {
+	if rb.size == len(rb.buf) {
+		rb.resize(max(2*len(rb.buf), 1))
+	}
+}
+
+func (rb *RingBuffer[T]) shrink() {
+	if len(rb.buf) > minCap && rb.size <= len(rb.buf)/4 && len(rb.buf)/2 >= rb.reserved {
+		rb.resize(max(len(rb.buf)/2, minCap))
+	}
+}
+
+func (rb *RingBuffer[T]) resize(capacity int) {
+	buf := make([]T, capacity)
+	if rb.size > 0 {
+		end := rb.head + rb.size
+		if end <= len(rb.buf) {
+			copy(buf, rb.buf[rb.head:end])
+		} else {
+			n := copy(buf, rb.buf[rb.head:])
+			copy(buf[n:], rb.buf[:end-len(rb.buf)])
+		}
+	}
+	rb.buf = buf
+	rb.head = 0
+}
@@ -0,0 +1,110 @@
+package ringbuffer
+
+import (
+	"slices"
+	"testing"
+)
+
+func contents[T any](rb *RingBuffer[T]) []T {
+	var ret []T
+	for i := 0; i < rb.Len(); i++ {
+		ret = append(ret, rb.At(i))
+	}
+	return ret
+}
+
+func TestPushPop(t *testing.T) {
+	rb := New[int](0)
+
+	rb.PushBack(2)
+	rb.PushBack(3)
+	rb.PushFront(1)
+	rb.PushFront(0)
+	rb.PushBack(4)
+	if got, want := contents(rb), []int{0, 1, 2, 3, 4}; !slices.Equal(got, want) {
+		t.Errorf("contents = %v, want %v", got, want)
+	}
+
+	if got := rb.PopFront(); got != 0 {
+		t.Errorf("rb.PopFront() = %d, want 0", got)
+	}
+	if got := rb.PopBack(); got != 4 {
+		t.Errorf("rb.PopBack() = %d, want 4", got)
+	}
+	if got, want := contents(rb), []int{1, 2, 3}; !slices.Equal(got, want) {
+		t.Errorf("contents = %v, want %v", got, want)
+	}
+}
+
+func TestWrapAround(t *testing.T) {
+	rb := New[int](4)
+
+	// simulate a long-running queue that never holds more than a few items
+	next, want := 0, 0
+	for i := 0; i < 1000; i++ {
+		rb.PushBack(next)
+		next++
+		if i%3 != 0 {
+			if got := rb.PopFront(); got != want {
+				t.Fatalf("rb.PopFront() = %d, want %d", got, want)
+			}
+			want++
+		}
+	}
+
+	if rb.Len() != next-want {
+		t.Errorf("rb.Len() = %d, want %d", rb.Len(), next-want)
+	}
+	for i := 0; i < rb.Len(); i++ {
+		if got := rb.At(i); got != want+i {
+			t.Errorf("rb.At(%d) = %d, want %d", i, got, want+i)
+		}
+	}
+}
+
+func TestCapacity(t *testing.T) {
+	rb := New[int](0)
+	for i := 0; i < 1000; i++ {
+		rb.PushBack(i)
+	}
+	if rb.Cap() < 1000 {
+		t.Errorf("rb.Cap() = %d, want >= 1000", rb.Cap())
+	}
+
+	for i := 0; i < 990; i++ {
+		rb.PopFront()
+	}
+	if rb.Cap() > 40 {
+		t.Errorf("rb.Cap() = %d after popping, want <= 40", rb.Cap())
+	}
+
+	rb.Reserve(100)
+	for i := 0; i < 10; i++ {
+		rb.PopBack()
+	}
+	if rb.Cap() < 100 {
+		t.Errorf("rb.Cap() = %d after Reserve(100), want >= 100", rb.Cap())
+	}
+
+	rb.PushBack(1)
+	rb.Shrink()
+	if rb.Cap() != 1 {
+		t.Errorf("rb.Cap() = %d after Shrink(), want 1", rb.Cap())
+	}
+}
+
+func TestNoAllocsWhenSmall(t *testing.T) {
+	rb := New[int](0)
+	rb.PushBack(1)
+	rb.PopBack()
+
+	allocs := testing.AllocsPerRun(1000, func() {
+		rb.PushBack(1)
+		rb.PushBack(2)
+		rb.PopBack()
+		rb.PopFront()
+	})
+	if allocs != 0 {
+		t.Errorf("pushing and popping on a small buffer made %v allocations per run, want 0", allocs)
+	}
+}
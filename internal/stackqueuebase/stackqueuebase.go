@@ -4,14 +4,32 @@ package stackqueuebase
 
 import (
 	"fmt"
+	"iter"
 	"strings"
+
+	"github.com/glennhartmann/aoclib/common"
+	"github.com/glennhartmann/aoclib/internal/containerfmt"
+	"github.com/glennhartmann/aoclib/internal/errs"
+	"github.com/glennhartmann/aoclib/internal/ringbuffer"
+)
+
+var (
+	// ErrEmpty is returned when popping or peeking from an empty stack or
+	// queue.
+	ErrEmpty = errs.ErrEmpty
+
+	// ErrOutOfRange is returned when popping or peeking more elements than
+	// the stack or queue holds.
+	ErrOutOfRange = errs.ErrOutOfRange
 )
 
-// Base implements stack and queue functionality, given the appropriate |impl|
-// helper.
+// Base implements stack and queue functionality, given the appropriate |h|
+// helper. Elements are stored in a ring buffer, in the order they were pushed,
+// so pushes and pops are amortized O(1) and memory use is proportional to the
+// number of elements currently stored.
 type Base[T any] struct {
-	impl []T
-	h    SQ[T]
+	rb *ringbuffer.RingBuffer[T]
+	h  SQ[T]
 }
 
 // NewBase creates a new Base object.
@@ -22,17 +40,36 @@ func NewBase[T any](h SQ[T]) *Base[T] {
 // NewBaseN creates a new Base object with |size| elements worth of
 // preallocated memory.
 func NewBaseN[T any](size int, h SQ[T]) *Base[T] {
-	return &Base[T]{make([]T, 0, size), h}
+	return &Base[T]{ringbuffer.New[T](size), h}
 }
 
 // Size returns the number of elements in the stack or queue.
 func (b *Base[T]) Size() int {
-	return len(b.impl)
+	return b.rb.Len()
+}
+
+// Cap returns the number of elements the stack or queue can hold without
+// allocating more memory.
+func (b *Base[T]) Cap() int {
+	return b.rb.Cap()
+}
+
+// Reserve ensures that the stack or queue can hold at least |n| elements
+// without allocating more memory, and that it won't automatically release
+// memory below that.
+func (b *Base[T]) Reserve(n int) {
+	b.rb.Reserve(n)
+}
+
+// Shrink releases any reserved memory, and reduces the capacity of the stack
+// or queue to the number of elements it currently holds.
+func (b *Base[T]) Shrink() {
+	b.rb.Shrink()
 }
 
 // Push pushes an item into the stack or queue.
 func (b *Base[T]) Push(v T) {
-	b.impl = append(b.impl, v)
+	b.rb.PushBack(v)
 }
 
 // PushN pushes N items into the stack or queue.
@@ -47,11 +84,12 @@ func (b *Base[T]) PushN(v ...T) {
 func (b *Base[T]) Pop() (T, error) {
 	if b.Empty() {
 		var r T
-		return r, fmt.Errorf("%s is empty", b.h.NameLower())
+		return r, fmt.Errorf("%s is %w", b.h.NameLower(), ErrEmpty)
 	}
-	v := b.h.Nth(b.impl, 0)
-	b.impl = b.h.Rest(b.impl)
-	return v, nil
+	if b.h.Index(b.Size(), 0) == 0 {
+		return b.rb.PopFront(), nil
+	}
+	return b.rb.PopBack(), nil
 }
 
 // Pop pops N items from the stack or queue. If the stack or queue has fewer
@@ -59,7 +97,7 @@ func (b *Base[T]) Pop() (T, error) {
 // unmodified.
 func (b *Base[T]) PopN(n int) ([]T, error) {
 	if n > b.Size() {
-		return nil, fmt.Errorf("can't pop %d elements - there are only %d in the %s", n, b.Size(), b.h.NameLower())
+		return nil, fmt.Errorf("can't pop %d elements - there are only %d in the %s: %w", n, b.Size(), b.h.NameLower(), ErrOutOfRange)
 	}
 	r := make([]T, 0, n)
 	for i := 0; i < n; i++ {
@@ -74,7 +112,7 @@ func (b *Base[T]) PopN(n int) ([]T, error) {
 
 // Empty returns whether or not the stack or queue is empty.
 func (b *Base[T]) Empty() bool {
-	return len(b.impl) == 0
+	return b.Size() == 0
 }
 
 // Pop returns an item from the stack or queue without removing it. If the
@@ -82,60 +120,185 @@ func (b *Base[T]) Empty() bool {
 func (b *Base[T]) Peek() (T, error) {
 	if b.Empty() {
 		var r T
-		return r, fmt.Errorf("%s is empty", b.h.NameLower())
+		return r, fmt.Errorf("%s is %w", b.h.NameLower(), ErrEmpty)
 	}
-	return b.h.Nth(b.impl, 0), nil
+	return b.at(0), nil
 }
 
 // Pop returns N items from the stack or queue without removing them. If the
 // stack or queue has fewer than N elements, an error is returned instead.
 func (b *Base[T]) PeekN(n int) ([]T, error) {
 	if n > b.Size() {
-		return nil, fmt.Errorf("can't peek %d elements - there are only %d in the %s", n, b.Size(), b.h.NameLower())
+		return nil, fmt.Errorf("can't peek %d elements - there are only %d in the %s: %w", n, b.Size(), b.h.NameLower(), ErrOutOfRange)
 	}
 
 	r := make([]T, 0, n)
 	for i := 0; i < n; i++ {
-		r = append(r, b.h.Nth(b.impl, i))
+		r = append(r, b.at(i))
 	}
 
 	return r, nil
 }
 
-// Join creates a string by combining each element from the stack or queue,
-// with |sep| between each pair.
-func (b *Base[T]) Join(sep string) string {
-	if b.Size() == 0 {
-		return ""
+// MustPop is like Pop, but panics if the stack or queue is empty.
+func (b *Base[T]) MustPop() T {
+	v, err := b.Pop()
+	if err != nil {
+		common.Panicf("MustPop: %v", err)
 	}
+	return v
+}
 
-	vals, err := b.PeekN(b.Size())
+// MustPopN is like PopN, but panics if the stack or queue has fewer than N
+// elements.
+func (b *Base[T]) MustPopN(n int) []T {
+	v, err := b.PopN(n)
 	if err != nil {
-		panic(fmt.Sprintf("bad %s: Size() = %d, but PeekN(%d) = %+v", b.h.NameLower(), b.Size(), b.Size(), err))
+		common.Panicf("MustPopN: %v", err)
 	}
+	return v
+}
+
+// MustPeek is like Peek, but panics if the stack or queue is empty.
+func (b *Base[T]) MustPeek() T {
+	v, err := b.Peek()
+	if err != nil {
+		common.Panicf("MustPeek: %v", err)
+	}
+	return v
+}
 
+// MustPeekN is like PeekN, but panics if the stack or queue has fewer than N
+// elements.
+func (b *Base[T]) MustPeekN(n int) []T {
+	v, err := b.PeekN(n)
+	if err != nil {
+		common.Panicf("MustPeekN: %v", err)
+	}
+	return v
+}
+
+// Join creates a string by combining each element from the stack or queue,
+// with |sep| between each pair.
+func (b *Base[T]) Join(sep string) string {
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("%v", vals[0]))
 
-	// TODO: add head and tail labels
-	for _, v := range vals[1:] {
-		sb.WriteString(fmt.Sprintf("%s%v", sep, v))
+	for i, v := range b.All() {
+		if i > 0 {
+			sb.WriteString(sep)
+		}
+		sb.WriteString(fmt.Sprintf("%v", v))
 	}
 
 	return sb.String()
 }
 
+// JoinLabeled is like Join, but labels which end of the stack or queue is
+// which (e.g. "top [3, 2, 1] bottom").
+func (b *Base[T]) JoinLabeled(sep string) string {
+	first, last := b.h.Ends()
+	return fmt.Sprintf("%s [%s] %s", first, b.Join(sep), last)
+}
+
+// String implements fmt.Stringer. It lists the elements in the order they
+// would be popped, labelling the ends.
+func (b *Base[T]) String() string {
+	return b.JoinLabeled(", ")
+}
+
+// Details returns the size and capacity of the stack or queue, as printed by
+// %+v.
+func (b *Base[T]) Details() string {
+	return fmt.Sprintf("%d items, capacity %d", b.Size(), b.Cap())
+}
+
+// Format implements fmt.Formatter. %v prints the same as String, %+v adds the
+// size and capacity, and %#v prints a Go-syntax representation.
+func (b *Base[T]) Format(f fmt.State, verb rune) {
+	name := b.h.NameLower()
+	typeName := name + "." + strings.ToUpper(name[:1]) + name[1:]
+	containerfmt.Format(f, verb, b, typeName, b.Values())
+}
+
+// All returns an iterator over the positions and values of the elements in the
+// stack or queue, in the order they would be popped. The stack or queue must
+// not be modified during iteration.
+func (b *Base[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < b.Size(); i++ {
+			if !yield(i, b.at(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Backward is like All, but iterates in the opposite order, starting with the
+// element that would be popped last.
+func (b *Base[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := b.Size() - 1; i >= 0; i-- {
+			if !yield(i, b.at(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values in the stack or queue, in the
+// order they would be popped.
+func (b *Base[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range b.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that pops elements from the stack or queue until
+// it is empty or iteration stops. Elements pushed during iteration will also
+// be popped, which makes Drain convenient for BFS or DFS loops.
+func (b *Base[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for !b.Empty() {
+			v, err := b.Pop()
+			if err != nil {
+				panic("this really shouldn't happen")
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// at returns the Nth item that would be popped.
+func (b *Base[T]) at(n int) T {
+	return b.rb.At(b.h.Index(b.Size(), n))
+}
+
 // SQ is an interface for the helper object that implements either
 // stack-specific or queue-specific functionality.
 type SQ[T any] interface {
 	// NameLower returns the name of this object ("stack" or "queue").
 	NameLower() string
 
+	// Ends returns labels for the end that is popped from first and the end
+	// that is popped from last.
+	Ends() (first, last string)
+
 	// Nth returns the Nth item from the slice.
 	Nth([]T, int) T
 
 	// Rest returns all but the first item from the slice.
 	Rest([]T) []T
+
+	// Index returns the index of the Nth item that would be popped, out of
+	// |size| items stored in the order they were pushed. Pop removes the item
+	// at Index(size, 0), which must be either the first or the last one.
+	Index(size, n int) int
 }
 
 // Stack implements SQ for a stack.
@@ -144,20 +307,32 @@ type Stack[T any] struct{}
 // NameLower returns the name of this object ("stack").
 func (Stack[T]) NameLower() string { return "stack" }
 
+// Ends returns labels for the ends of the stack ("top" and "bottom").
+func (Stack[T]) Ends() (first, last string) { return "top", "bottom" }
+
 // Nth returns the Nth item from the slice.
 func (Stack[T]) Nth(impl []T, n int) T { return impl[len(impl)-n-1] }
 
 // Rest returns all but the first item from the slice.
 func (Stack[T]) Rest(impl []T) []T { return impl[:len(impl)-1] }
 
+// Index returns the index of the Nth item that would be popped.
+func (Stack[T]) Index(size, n int) int { return size - n - 1 }
+
 // Queue implements SQ for a queue.
 type Queue[T any] struct{}
 
 // NameLower returns the name of this object ("queue").
 func (Queue[T]) NameLower() string { return "queue" }
 
+// Ends returns labels for the ends of the queue ("front" and "back").
+func (Queue[T]) Ends() (first, last string) { return "front", "back" }
+
 // Nth returns the Nth item from the slice.
 func (Queue[T]) Nth(impl []T, n int) T { return impl[n] }
 
 // Rest returns all but the first item from the slice.
 func (Queue[T]) Rest(impl []T) []T { return impl[1:] }
+
+// Index returns the index of the Nth item that would be popped.
+func (Queue[T]) Index(size, n int) int { return n }
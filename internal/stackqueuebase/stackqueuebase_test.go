@@ -1,6 +1,8 @@
 package stackqueuebase
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	"golang.org/x/exp/slices"
@@ -525,3 +527,193 @@ func TestStackJoin(t *testing.T) {
 		t.Errorf("q.Join(%q) = %q, want %q", sep, got, want2)
 	}
 }
+
+func TestIterators(t *testing.T) {
+	tests := []struct {
+		name         string
+		b            *Base[string]
+		wantAll      []string
+		wantBackward []string
+	}{
+		{
+			name:         "queue",
+			b:            setupQueue(),
+			wantAll:      []string{"a", "b", "3", "z"},
+			wantBackward: []string{"z", "3", "b", "a"},
+		},
+		{
+			name:         "stack",
+			b:            setupStack(),
+			wantAll:      []string{"z", "3", "b", "a"},
+			wantBackward: []string{"a", "b", "3", "z"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var gotAll []string
+			for i, v := range test.b.All() {
+				if v != test.wantAll[i] {
+					t.Errorf("All() yielded (%d, %q), want (%d, %q)", i, v, i, test.wantAll[i])
+				}
+				gotAll = append(gotAll, v)
+			}
+			if !slices.Equal(gotAll, test.wantAll) {
+				t.Errorf("All() = %v, want %v", gotAll, test.wantAll)
+			}
+
+			var gotBackward []string
+			for i, v := range test.b.Backward() {
+				if v != test.wantAll[i] {
+					t.Errorf("Backward() yielded (%d, %q), want (%d, %q)", i, v, i, test.wantAll[i])
+				}
+				gotBackward = append(gotBackward, v)
+			}
+			if !slices.Equal(gotBackward, test.wantBackward) {
+				t.Errorf("Backward() = %v, want %v", gotBackward, test.wantBackward)
+			}
+
+			if test.b.Size() != len(test.wantAll) {
+				t.Errorf("b.Size() = %d after iterating, want %d", test.b.Size(), len(test.wantAll))
+			}
+
+			var gotDrain []string
+			for v := range test.b.Drain() {
+				gotDrain = append(gotDrain, v)
+			}
+			if !slices.Equal(gotDrain, test.wantAll) {
+				t.Errorf("Drain() = %v, want %v", gotDrain, test.wantAll)
+			}
+			if !test.b.Empty() {
+				t.Errorf("b.Empty() = false after Drain()")
+			}
+		})
+	}
+}
+
+func TestQueueMemoryBounded(t *testing.T) {
+	q := NewBase[int](Queue[int]{})
+
+	next, want := 0, 0
+	for i := 0; i < 100000; i++ {
+		q.PushN(next, next+1)
+		next += 2
+		for j := 0; j < 2 && q.Size() > 3; j++ {
+			got, err := q.Pop()
+			if err != nil || got != want {
+				t.Fatalf("q.Pop() = %d, %v, want %d, nil", got, err, want)
+			}
+			want++
+		}
+	}
+
+	if q.Cap() > 16 {
+		t.Errorf("q.Cap() = %d with %d items, want <= 16", q.Cap(), q.Size())
+	}
+
+	q.Reserve(1000)
+	if q.Cap() < 1000 {
+		t.Errorf("q.Cap() = %d after q.Reserve(1000), want >= 1000", q.Cap())
+	}
+
+	q.Shrink()
+	if q.Cap() != q.Size() {
+		t.Errorf("q.Cap() = %d after q.Shrink(), want %d", q.Cap(), q.Size())
+	}
+}
+
+func TestStackPushPopNoAllocs(t *testing.T) {
+	s := NewBase[int](Stack[int]{})
+	s.Push(1)
+	s.Pop()
+
+	allocs := testing.AllocsPerRun(1000, func() {
+		s.Push(1)
+		if _, err := s.Pop(); err != nil {
+			t.Fatalf("s.Pop() error = %v", err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("s.Push() and s.Pop() on an empty stack made %v allocations per run, want 0", allocs)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	q := setupQueue()
+
+	if _, err := q.PopN(5); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("q.PopN(5) = err(%v), want ErrOutOfRange", err)
+	}
+	if _, err := q.PeekN(5); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("q.PeekN(5) = err(%v), want ErrOutOfRange", err)
+	}
+
+	if got := q.MustPeek(); got != "a" {
+		t.Errorf("q.MustPeek() = %q, want \"a\"", got)
+	}
+	if got := q.MustPopN(3); !slices.Equal(got, []string{"a", "b", "3"}) {
+		t.Errorf("q.MustPopN(3) = %v, want [a b 3]", got)
+	}
+	if got := q.MustPop(); got != "z" {
+		t.Errorf("q.MustPop() = %q, want \"z\"", got)
+	}
+
+	if _, err := q.Peek(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("q.Peek() = err(%v), want ErrEmpty", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("q.MustPop() on empty queue didn't panic")
+		}
+	}()
+	q.MustPop()
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		b      *Base[string]
+		format string
+		want   string
+	}{
+		{
+			name:   "queue %v",
+			b:      setupQueue(),
+			format: "%v",
+			want:   "front [a, b, 3, z] back",
+		},
+		{
+			name:   "stack %s",
+			b:      setupStack(),
+			format: "%s",
+			want:   "top [z, 3, b, a] bottom",
+		},
+		{
+			name:   "queue %+v",
+			b:      setupQueue(),
+			format: "%+v",
+			want:   "front [a, b, 3, z] back (4 items, capacity 4)",
+		},
+		{
+			name:   "stack %#v",
+			b:      setupStack(),
+			format: "%#v",
+			want:   `stack.Stack[string]{"z", "3", "b", "a"}`,
+		},
+		{
+			name:   "empty queue",
+			b:      NewBase[string](Queue[string]{}),
+			format: "%v",
+			want:   "front [] back",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := fmt.Sprintf(test.format, test.b); got != test.want {
+				t.Errorf("fmt.Sprintf(%q, b) = %q, want %q", test.format, got, test.want)
+			}
+		})
+	}
+}
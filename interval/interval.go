@@ -0,0 +1,119 @@
+// Package interval implements generic inclusive integer ranges, and sets of
+// them that are kept merged and sorted.
+package interval
+
+import (
+	"fmt"
+
+	"github.com/glennhartmann/aoclib/common"
+)
+
+// Interval is the inclusive range of integers [Lo, Hi]. An Interval with
+// Lo > Hi is empty.
+type Interval[T common.Integer] struct {
+	Lo, Hi T
+}
+
+// New returns the Interval [lo, hi].
+func New[T common.Integer](lo, hi T) Interval[T] {
+	return Interval[T]{lo, hi}
+}
+
+// FromLen returns the Interval of |length| integers starting at |start|, as
+// in inputs that give ranges as "start length". If |length| isn't positive,
+// the Interval is empty.
+func FromLen[T common.Integer](start, length T) Interval[T] {
+	if length <= 0 {
+		return Interval[T]{1, 0}
+	}
+	return Interval[T]{start, start + length - 1}
+}
+
+// Empty returns whether or not the Interval contains no integers.
+func (iv Interval[T]) Empty() bool { return iv.Lo > iv.Hi }
+
+// Len returns the number of integers in the Interval.
+func (iv Interval[T]) Len() T {
+	if iv.Empty() {
+		return 0
+	}
+	return iv.Hi - iv.Lo + 1
+}
+
+// Contains returns whether or not |x| is in the Interval.
+func (iv Interval[T]) Contains(x T) bool { return iv.Lo <= x && x <= iv.Hi }
+
+// ContainsInterval returns whether or not every integer in |o| is also in the
+// Interval. An empty Interval is contained by everything.
+func (iv Interval[T]) ContainsInterval(o Interval[T]) bool {
+	return o.Empty() || (iv.Lo <= o.Lo && o.Hi <= iv.Hi)
+}
+
+// Overlaps returns whether or not the Interval and |o| have any integers in
+// common.
+func (iv Interval[T]) Overlaps(o Interval[T]) bool {
+	_, ok := iv.Intersect(o)
+	return ok
+}
+
+// Touches returns whether or not the Interval and |o| overlap or are directly
+// next to each other (like [1, 3] and [4, 6]), so that their union is a
+// single Interval.
+func (iv Interval[T]) Touches(o Interval[T]) bool {
+	if iv.Empty() || o.Empty() {
+		return false
+	}
+	if iv.Lo > o.Lo {
+		iv, o = o, iv
+	}
+	return o.Lo <= iv.Hi || o.Lo-iv.Hi == 1
+}
+
+// Intersect returns the integers in both the Interval and |o|, and whether or
+// not there are any.
+func (iv Interval[T]) Intersect(o Interval[T]) (Interval[T], bool) {
+	ret := Interval[T]{common.Max(iv.Lo, o.Lo), common.Min(iv.Hi, o.Hi)}
+	return ret, !ret.Empty()
+}
+
+// Union returns the smallest Interval containing both the Interval and |o|,
+// and whether or not that's exactly their union (that is, whether or not they
+// touch). Unions of empty Intervals are whatever the other Interval is.
+func (iv Interval[T]) Union(o Interval[T]) (Interval[T], bool) {
+	switch {
+	case iv.Empty():
+		return o, true
+	case o.Empty():
+		return iv, true
+	}
+	return Interval[T]{common.Min(iv.Lo, o.Lo), common.Max(iv.Hi, o.Hi)}, iv.Touches(o)
+}
+
+// Subtract returns the integers in the Interval but not in |o|, as zero, one
+// or two non-empty Intervals in increasing order.
+func (iv Interval[T]) Subtract(o Interval[T]) []Interval[T] {
+	if iv.Empty() {
+		return nil
+	}
+	if !iv.Overlaps(o) {
+		return []Interval[T]{iv}
+	}
+
+	var ret []Interval[T]
+	if iv.Lo < o.Lo {
+		ret = append(ret, Interval[T]{iv.Lo, o.Lo - 1})
+	}
+	if o.Hi < iv.Hi {
+		ret = append(ret, Interval[T]{o.Hi + 1, iv.Hi})
+	}
+	return ret
+}
+
+// Shift returns the Interval moved by |d|.
+func (iv Interval[T]) Shift(d T) Interval[T] {
+	return Interval[T]{iv.Lo + d, iv.Hi + d}
+}
+
+func (iv Interval[T]) String() string {
+	return fmt.Sprintf("[%d, %d]", iv.Lo, iv.Hi)
+}
@@ -0,0 +1,62 @@
+package interval
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestInterval(t *testing.T) {
+	a := New(3, 7)
+
+	if got := a.Len(); got != 5 {
+		t.Errorf("%v.Len() = %d, want 5", a, got)
+	}
+	if got := FromLen(3, 5); got != a {
+		t.Errorf("FromLen(3, 5) = %v, want %v", got, a)
+	}
+	if !New(1, 0).Empty() || New(1, 0).Len() != 0 {
+		t.Errorf("New(1, 0) isn't empty")
+	}
+	if got := FromLen[uint](0, 0); !got.Empty() || got.Len() != 0 {
+		t.Errorf("FromLen[uint](0, 0) = %v, want empty", got)
+	}
+	if got := FromLen[int8](-128, 0); !got.Empty() || got.Len() != 0 {
+		t.Errorf("FromLen[int8](-128, 0) = %v, want empty", got)
+	}
+
+	tests := []struct {
+		name      string
+		b         Interval[int]
+		intersect Interval[int]
+		overlaps  bool
+		touches   bool
+		subtract  []Interval[int]
+	}{
+		{name: "disjoint", b: New(10, 12), overlaps: false, touches: false, subtract: []Interval[int]{a}},
+		{name: "adjacent", b: New(8, 12), overlaps: false, touches: true, subtract: []Interval[int]{a}},
+		{name: "overlap right", b: New(6, 12), intersect: New(6, 7), overlaps: true, touches: true, subtract: []Interval[int]{New(3, 5)}},
+		{name: "overlap left", b: New(0, 3), intersect: New(3, 3), overlaps: true, touches: true, subtract: []Interval[int]{New(4, 7)}},
+		{name: "inside", b: New(4, 5), intersect: New(4, 5), overlaps: true, touches: true, subtract: []Interval[int]{New(3, 3), New(6, 7)}},
+		{name: "covering", b: New(0, 10), intersect: a, overlaps: true, touches: true, subtract: nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := a.Overlaps(test.b); got != test.overlaps {
+				t.Errorf("%v.Overlaps(%v) = %v, want %v", a, test.b, got, test.overlaps)
+			}
+			if got := a.Touches(test.b); got != test.touches {
+				t.Errorf("%v.Touches(%v) = %v, want %v", a, test.b, got, test.touches)
+			}
+			if got, ok := a.Intersect(test.b); ok && got != test.intersect {
+				t.Errorf("%v.Intersect(%v) = %v, want %v", a, test.b, got, test.intersect)
+			}
+			if got := a.Subtract(test.b); !slices.Equal(got, test.subtract) {
+				t.Errorf("%v.Subtract(%v) = %v, want %v", a, test.b, got, test.subtract)
+			}
+			if _, ok := a.Union(test.b); ok != test.touches {
+				t.Errorf("%v.Union(%v) ok = %v, want %v", a, test.b, ok, test.touches)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package interval
+
+import (
+	"iter"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/glennhartmann/aoclib/common"
+)
+
+// Set is a set of integers, stored as a sorted list of disjoint, non-touching
+// Intervals. The zero value is an empty Set ready to use.
+type Set[T common.Integer] struct {
+	ivs []Interval[T]
+}
+
+// NewSet creates a Set containing the union of |ivs|.
+func NewSet[T common.Integer](ivs ...Interval[T]) *Set[T] {
+	s := &Set[T]{}
+	for _, iv := range ivs {
+		s.Add(iv)
+	}
+	return s
+}
+
+// Clone returns a copy of the Set.
+func (s *Set[T]) Clone() *Set[T] {
+	return &Set[T]{slices.Clone(s.ivs)}
+}
+
+// Intervals returns a copy of the Set's Intervals, in increasing order.
+func (s *Set[T]) Intervals() []Interval[T] { return slices.Clone(s.ivs) }
+
+// All returns an iterator over the Set's Intervals, in increasing order. The
+// Set must not be modified during iteration.
+func (s *Set[T]) All() iter.Seq[Interval[T]] { return slices.Values(s.ivs) }
+
+// Empty returns whether or not the Set contains no integers.
+func (s *Set[T]) Empty() bool { return len(s.ivs) == 0 }
+
+// Len returns the number of integers in the Set.
+func (s *Set[T]) Len() T {
+	var ret T
+	for _, iv := range s.ivs {
+		ret += iv.Len()
+	}
+	return ret
+}
+
+// Min returns the smallest integer in the Set, and whether or not there is
+// one.
+func (s *Set[T]) Min() (T, bool) {
+	if s.Empty() {
+		return 0, false
+	}
+	return s.ivs[0].Lo, true
+}
+
+// Max returns the largest integer in the Set, and whether or not there is
+// one.
+func (s *Set[T]) Max() (T, bool) {
+	if s.Empty() {
+		return 0, false
+	}
+	return s.ivs[len(s.ivs)-1].Hi, true
+}
+
+// Contains returns whether or not |x| is in the Set.
+func (s *Set[T]) Contains(x T) bool {
+	i := sort.Search(len(s.ivs), func(i int) bool { return s.ivs[i].Hi >= x })
+	return i < len(s.ivs) && s.ivs[i].Contains(x)
+}
+
+// ContainsInterval returns whether or not every integer in |iv| is in the
+// Set.
+func (s *Set[T]) ContainsInterval(iv Interval[T]) bool {
+	if iv.Empty() {
+		return true
+	}
+	i := sort.Search(len(s.ivs), func(i int) bool { return s.ivs[i].Hi >= iv.Lo })
+	return i < len(s.ivs) && s.ivs[i].ContainsInterval(iv)
+}
+
+// span returns the range [i, j) of the Set's Intervals that |iv| overlaps
+// (or, if |touching| is true, touches).
+func (s *Set[T]) span(iv Interval[T], touching bool) (i, j int) {
+	hits := iv.Overlaps
+	if touching {
+		hits = iv.Touches
+	}
+	i = sort.Search(len(s.ivs), func(i int) bool { return s.ivs[i].Hi >= iv.Lo || hits(s.ivs[i]) })
+	j = i
+	for j < len(s.ivs) && hits(s.ivs[j]) {
+		j++
+	}
+	return i, j
+}
+
+// Add adds every integer in |iv| to the Set.
+func (s *Set[T]) Add(iv Interval[T]) {
+	if iv.Empty() {
+		return
+	}
+	i, j := s.span(iv, true)
+	for _, o := range s.ivs[i:j] {
+		iv, _ = iv.Union(o)
+	}
+	s.ivs = slices.Replace(s.ivs, i, j, iv)
+}
+
+// Remove removes every integer in |iv| from the Set.
+func (s *Set[T]) Remove(iv Interval[T]) {
+	if iv.Empty() {
+		return
+	}
+	i, j := s.span(iv, false)
+	var pieces []Interval[T]
+	for _, o := range s.ivs[i:j] {
+		pieces = append(pieces, o.Subtract(iv)...)
+	}
+	s.ivs = slices.Replace(s.ivs, i, j, pieces...)
+}
+
+// Union returns a new Set containing the integers in either the Set or |o|.
+func (s *Set[T]) Union(o *Set[T]) *Set[T] {
+	ret := s.Clone()
+	for _, iv := range o.ivs {
+		ret.Add(iv)
+	}
+	return ret
+}
+
+// Intersect returns a new Set containing the integers in both the Set and
+// |o|.
+func (s *Set[T]) Intersect(o *Set[T]) *Set[T] {
+	ret := &Set[T]{}
+	for i, j := 0, 0; i < len(s.ivs) && j < len(o.ivs); {
+		if iv, ok := s.ivs[i].Intersect(o.ivs[j]); ok {
+			ret.ivs = append(ret.ivs, iv)
+		}
+		if s.ivs[i].Hi < o.ivs[j].Hi {
+			i++
+		} else {
+			j++
+		}
+	}
+	return ret
+}
+
+// Subtract returns a new Set containing the integers in the Set but not in
+// |o|.
+func (s *Set[T]) Subtract(o *Set[T]) *Set[T] {
+	ret := s.Clone()
+	for _, iv := range o.ivs {
+		ret.Remove(iv)
+	}
+	return ret
+}
+
+// Shift returns a new Set with every integer moved by |d|.
+func (s *Set[T]) Shift(d T) *Set[T] {
+	ret := &Set[T]{make([]Interval[T], 0, len(s.ivs))}
+	for _, iv := range s.ivs {
+		ret.ivs = append(ret.ivs, iv.Shift(d))
+	}
+	return ret
+}
+
+// Mapping maps each integer x in Src to x - Src.Lo + Dst.
+type Mapping[T common.Integer] struct {
+	Src Interval[T]
+	Dst T
+}
+
+// Map returns a new Set containing the image of every integer in the Set
+// under |ms|. Integers covered by a Mapping are moved by the first Mapping
+// that covers them, and integers not covered by any are left where they are.
+// This is useful for puzzles that push whole ranges of seeds through a chain
+// of mapping tables.
+func (s *Set[T]) Map(ms []Mapping[T]) *Set[T] {
+	ret := &Set[T]{}
+	rest := s.Clone()
+	for _, m := range ms {
+		i, j := rest.span(m.Src, false)
+		for _, iv := range rest.ivs[i:j] {
+			in, _ := iv.Intersect(m.Src)
+			ret.Add(in.Shift(m.Dst - m.Src.Lo))
+		}
+		rest.Remove(m.Src)
+	}
+	return ret.Union(rest)
+}
+
+func (s *Set[T]) String() string {
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i, iv := range s.ivs {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(iv.String())
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
@@ -0,0 +1,95 @@
+package interval
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+	s := NewSet(New(10, 20), New(1, 3), New(4, 5), New(30, 40))
+	if got, want := s.String(), "{[1, 5], [10, 20], [30, 40]}"; got != want {
+		t.Errorf("NewSet() = %s, want %s", got, want)
+	}
+
+	s.Add(New(18, 31))
+	s.Remove(New(2, 2))
+	if got, want := s.Intervals(), []Interval[int]{{1, 1}, {3, 5}, {10, 40}}; !slices.Equal(got, want) {
+		t.Errorf("s.Intervals() = %v, want %v", got, want)
+	}
+	if got := s.Len(); got != 35 {
+		t.Errorf("s.Len() = %d, want 35", got)
+	}
+	if !s.Contains(4) || s.Contains(2) || s.Contains(41) {
+		t.Errorf("s.Contains() gave wrong results for %v", s)
+	}
+	if !s.ContainsInterval(New(12, 40)) || s.ContainsInterval(New(5, 10)) {
+		t.Errorf("s.ContainsInterval() gave wrong results for %v", s)
+	}
+}
+
+// TestSetRandom checks Set operations against a map-based implementation.
+func TestSetRandom(t *testing.T) {
+	const maxVal = 60
+	r := rand.New(rand.NewSource(1))
+	randomInterval := func() Interval[int] {
+		lo := r.Intn(maxVal)
+		return New(lo, lo+r.Intn(10))
+	}
+
+	for round := 0; round < 100; round++ {
+		a, b := &Set[int]{}, &Set[int]{}
+		am, bm := make(map[int]bool), make(map[int]bool)
+		for i := 0; i < 5; i++ {
+			for _, sm := range []struct {
+				s *Set[int]
+				m map[int]bool
+			}{{a, am}, {b, bm}} {
+				iv := randomInterval()
+				add := r.Intn(3) > 0
+				if add {
+					sm.s.Add(iv)
+				} else {
+					sm.s.Remove(iv)
+				}
+				for x := iv.Lo; x <= iv.Hi; x++ {
+					sm.m[x] = add
+				}
+			}
+		}
+
+		check := func(name string, s *Set[int], want func(x int) bool) {
+			ivs := s.Intervals()
+			for i := 1; i < len(ivs); i++ {
+				if ivs[i-1].Touches(ivs[i]) || ivs[i-1].Lo > ivs[i].Lo {
+					t.Fatalf("%s = %v isn't normalized", name, s)
+				}
+			}
+			for x := -1; x <= maxVal+10; x++ {
+				if got := s.Contains(x); got != want(x) {
+					t.Fatalf("%s = %v, Contains(%d) = %v, want %v", name, s, x, got, want(x))
+				}
+			}
+		}
+
+		check("a", a, func(x int) bool { return am[x] })
+		check("a.Union(b)", a.Union(b), func(x int) bool { return am[x] || bm[x] })
+		check("a.Intersect(b)", a.Intersect(b), func(x int) bool { return am[x] && bm[x] })
+		check("a.Subtract(b)", a.Subtract(b), func(x int) bool { return am[x] && !bm[x] })
+	}
+}
+
+func TestSetMap(t *testing.T) {
+	// The seed-to-soil map from the 2023 day 5 example.
+	ms := []Mapping[int]{
+		{Src: FromLen(98, 2), Dst: 50},
+		{Src: FromLen(50, 48), Dst: 52},
+	}
+
+	s := NewSet(FromLen(79, 14), FromLen(55, 13), New(0, 1), New(98, 99))
+	got := s.Map(ms)
+	want := NewSet(FromLen(81, 14), FromLen(57, 13), New(0, 1), New(50, 51))
+	if !slices.Equal(got.Intervals(), want.Intervals()) {
+		t.Errorf("s.Map() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,106 @@
+package mathx
+
+import (
+	"fmt"
+	"math/big"
+
+	"golang.org/x/exp/constraints"
+)
+
+// CRT solves the system of congruences x ≡ residues[i] (mod moduli[i]) using
+// the Chinese Remainder Theorem. It returns the smallest non-negative solution
+// x, along with the modulus m (the LCM of |moduli|) such that every solution
+// is congruent to x mod m. The moduli don't need to be pairwise coprime.
+//
+// Intermediate products never overflow. If m doesn't fit in T, CRT falls back
+// to CRTBig to find x. If x fits in T, it's returned along with an error
+// wrapping ErrModulusOverflow. That x is the only non-negative solution that
+// fits in T, and m is meaningless. Use CRTBig if the period is needed.
+//
+// It returns ErrNoSolution if the congruences are inconsistent. It returns
+// an error wrapping ErrOverflow (as ErrModulusOverflow also does) if x
+// doesn't fit in T either.
+func CRT[T constraints.Signed](residues, moduli []T) (x, m T, err error) {
+	if len(residues) != len(moduli) {
+		return 0, 0, fmt.Errorf("CRT: got %d residues but %d moduli", len(residues), len(moduli))
+	}
+
+	x, m = 0, 1
+	for i, n := range moduli {
+		if n <= 0 {
+			return 0, 0, fmt.Errorf("CRT: modulus %d must be positive, got %d", i, n)
+		}
+
+		// We need x + m*k ≡ r (mod n), i.e. m*k ≡ d (mod n).
+		g, p, _ := ExtendedGCD(m, n)
+		d := subMod(Mod(residues[i], n), Mod(x, n), n)
+		if d%g != 0 {
+			return 0, 0, fmt.Errorf("CRT: congruence %d (x ≡ %d mod %d): %w", i, residues[i], n, ErrNoSolution)
+		}
+
+		ng := n / g
+		l := m * ng
+		if l/ng != m || l <= 0 {
+			return crtViaBig(residues, moduli)
+		}
+
+		k := mulMod(d/g, Mod(p, ng), ng)
+		x += m * k
+		m = l
+	}
+
+	return x, m, nil
+}
+
+// crtViaBig solves the congruences with CRTBig, for when the combined modulus
+// doesn't fit in T, and converts x back to T if possible. It always returns a
+// non-nil error.
+func crtViaBig[T constraints.Signed](residues, moduli []T) (x, m T, err error) {
+	br := make([]*big.Int, len(residues))
+	bm := make([]*big.Int, len(moduli))
+	for i := range residues {
+		br[i] = big.NewInt(int64(residues[i]))
+		bm[i] = big.NewInt(int64(moduli[i]))
+	}
+
+	bx, _, err := CRTBig(br, bm)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !bx.IsInt64() || int64(T(bx.Int64())) != bx.Int64() {
+		return 0, 0, fmt.Errorf("CRT: solution %v of %v: %w", bx, moduli, ErrOverflow)
+	}
+	return T(bx.Int64()), 0, fmt.Errorf("CRT: combined modulus of %v: %w", moduli, ErrModulusOverflow)
+}
+
+// CRTBig is like CRT, but for arbitrary-precision integers, so it can never
+// overflow.
+func CRTBig(residues, moduli []*big.Int) (x, m *big.Int, err error) {
+	if len(residues) != len(moduli) {
+		return nil, nil, fmt.Errorf("CRTBig: got %d residues but %d moduli", len(residues), len(moduli))
+	}
+
+	x, m = big.NewInt(0), big.NewInt(1)
+	g, p, d, tmp := new(big.Int), new(big.Int), new(big.Int), new(big.Int)
+	for i, n := range moduli {
+		if n.Sign() <= 0 {
+			return nil, nil, fmt.Errorf("CRTBig: modulus %d must be positive, got %v", i, n)
+		}
+
+		g.GCD(p, nil, m, n)
+		d.Sub(residues[i], x)
+		d.Mod(d, n)
+		if tmp.Mod(d, g).Sign() != 0 {
+			return nil, nil, fmt.Errorf("CRTBig: congruence %d (x ≡ %v mod %v): %w", i, residues[i], n, ErrNoSolution)
+		}
+
+		ng := new(big.Int).Quo(n, g)
+		k := d.Quo(d, g)
+		k.Mul(k, p)
+		k.Mod(k, ng)
+		x.Add(x, tmp.Mul(m, k))
+		m.Mul(m, ng)
+	}
+
+	return x, m, nil
+}
@@ -0,0 +1,148 @@
+// Package mathx implements generic integer and number-theory helpers: GCD and
+// LCM, modular arithmetic, the Chinese Remainder Theorem, and prime sieving,
+// factorization and divisors.
+package mathx
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+
+	"github.com/glennhartmann/aoclib/common"
+	"golang.org/x/exp/constraints"
+)
+
+var (
+	// ErrNoSolution is returned by CRT and CRTBig when the congruences are
+	// inconsistent.
+	ErrNoSolution = errors.New("no solution")
+
+	// ErrOverflow is returned by CRT when the solution doesn't fit in the
+	// integer type. Use CRTBig instead.
+	ErrOverflow = errors.New("overflow")
+
+	// ErrModulusOverflow is returned by CRT when the solution fits in the
+	// integer type but the combined modulus doesn't. It wraps ErrOverflow.
+	ErrModulusOverflow = fmt.Errorf("modulus %w", ErrOverflow)
+)
+
+// GCD returns the (non-negative) greatest common divisor of |a| and |b|.
+// GCD(0, 0) is 0.
+func GCD[T constraints.Integer](a, b T) T {
+	a, b = common.Abs(a), common.Abs(b)
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// LCM returns the (non-negative) least common multiple of |a| and |b|. If
+// either is 0, LCM returns 0.
+func LCM[T constraints.Integer](a, b T) T {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return common.Abs(a / GCD(a, b) * b)
+}
+
+// SliceGCD returns the GCD of all the elements of |slice|, or 0 if it's
+// empty.
+func SliceGCD[T constraints.Integer](slice []T) T {
+	var ret T
+	for _, n := range slice {
+		ret = GCD(ret, n)
+	}
+	return ret
+}
+
+// SliceLCM returns the LCM of all the elements of |slice|, or 1 if it's empty.
+// This is the usual answer to "when do all these cycles line up?".
+func SliceLCM[T constraints.Integer](slice []T) T {
+	var ret T = 1
+	for _, n := range slice {
+		ret = LCM(ret, n)
+	}
+	return ret
+}
+
+// ExtendedGCD returns g = GCD(a, b), along with Bézout coefficients x and y
+// such that a*x + b*y == g.
+func ExtendedGCD[T constraints.Signed](a, b T) (g, x, y T) {
+	oldR, r := a, b
+	oldS, s := T(1), T(0)
+	oldT, t := T(0), T(1)
+	for r != 0 {
+		q := oldR / r
+		oldR, r = r, oldR-q*r
+		oldS, s = s, oldS-q*s
+		oldT, t = t, oldT-q*t
+	}
+	if oldR < 0 {
+		return -oldR, -oldS, -oldT
+	}
+	return oldR, oldS, oldT
+}
+
+// Mod returns |a| modulo |m|, which, unlike a % m, is never negative. It
+// panics if |m| isn't positive.
+func Mod[T constraints.Integer](a, m T) T {
+	mustBePositiveModulus(m)
+	r := a % m
+	if r < 0 {
+		r += m
+	}
+	return r
+}
+
+// ModInverse returns x such that a*x ≡ 1 (mod m), and whether or not there is
+// one (which is the case if and only if |a| and |m| are coprime). It panics if
+// |m| isn't positive.
+func ModInverse[T constraints.Signed](a, m T) (T, bool) {
+	mustBePositiveModulus(m)
+	g, x, _ := ExtendedGCD(Mod(a, m), m)
+	if g != 1 {
+		return 0, false
+	}
+	return Mod(x, m), true
+}
+
+// ModPow returns |base| to the power of |exp|, modulo |m|, without
+// overflowing. It panics if |exp| is negative or |m| isn't positive.
+func ModPow[T constraints.Integer](base, exp, m T) T {
+	mustBePositiveModulus(m)
+	if exp < 0 {
+		common.Panicf("ModPow: negative exponent %d", exp)
+	}
+
+	ret := Mod(1, m)
+	base = Mod(base, m)
+	for ; exp > 0; exp >>= 1 {
+		if exp&1 == 1 {
+			ret = mulMod(ret, base, m)
+		}
+		base = mulMod(base, base, m)
+	}
+	return ret
+}
+
+// mulMod returns a*b modulo |m| without overflowing. |a| and |b| must already
+// be in [0, m).
+func mulMod[T constraints.Integer](a, b, m T) T {
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	return T(bits.Rem64(hi, lo, uint64(m)))
+}
+
+// subMod returns a-b modulo |m| without overflowing. |a| and |b| must already
+// be in [0, m).
+func subMod[T constraints.Integer](a, b, m T) T {
+	if a >= b {
+		return a - b
+	}
+	return a + (m - b)
+}
+
+func mustBePositiveModulus[T constraints.Integer](m T) {
+	if m <= 0 {
+		common.Panicf("modulus must be positive, got %d", m)
+	}
+}
@@ -0,0 +1,150 @@
+package mathx
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"slices"
+	"testing"
+)
+
+func TestGCDAndLCM(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     int
+		gcd, lcm int
+	}{
+		{name: "coprime", a: 8, b: 15, gcd: 1, lcm: 120},
+		{name: "common factor", a: 12, b: 18, gcd: 6, lcm: 36},
+		{name: "negative", a: -12, b: 18, gcd: 6, lcm: 36},
+		{name: "zero", a: 0, b: 7, gcd: 7, lcm: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := GCD(test.a, test.b); got != test.gcd {
+				t.Errorf("GCD(%d, %d) = %d, want %d", test.a, test.b, got, test.gcd)
+			}
+			if got := LCM(test.a, test.b); got != test.lcm {
+				t.Errorf("LCM(%d, %d) = %d, want %d", test.a, test.b, got, test.lcm)
+			}
+		})
+	}
+
+	if got, want := SliceLCM([]int64{4, 6, 10, 7}), int64(420); got != want {
+		t.Errorf("SliceLCM() = %d, want %d", got, want)
+	}
+	if got := SliceGCD([]uint{12, 18, 30}); got != 6 {
+		t.Errorf("SliceGCD() = %d, want 6", got)
+	}
+}
+
+func TestExtendedGCD(t *testing.T) {
+	for _, ab := range [][2]int{{240, 46}, {-240, 46}, {17, 0}, {0, 5}, {35, 64}} {
+		a, b := ab[0], ab[1]
+		g, x, y := ExtendedGCD(a, b)
+		if g != GCD(a, b) || a*x+b*y != g {
+			t.Errorf("ExtendedGCD(%d, %d) = %d, %d, %d, want g = %d and a*x + b*y == g", a, b, g, x, y, GCD(a, b))
+		}
+	}
+}
+
+func TestModular(t *testing.T) {
+	if got := Mod(-7, 5); got != 3 {
+		t.Errorf("Mod(-7, 5) = %d, want 3", got)
+	}
+
+	if got, ok := ModInverse(3, 11); !ok || got != 4 {
+		t.Errorf("ModInverse(3, 11) = %d, %v, want 4, true", got, ok)
+	}
+	if _, ok := ModInverse(6, 9); ok {
+		t.Errorf("ModInverse(6, 9) = ok, want no inverse")
+	}
+
+	if got := ModPow(4, 13, 497); got != 445 {
+		t.Errorf("ModPow(4, 13, 497) = %d, want 445", got)
+	}
+
+	// (2^62)^2 overflows int64, so this checks that mulMod doesn't.
+	const m = math.MaxInt64
+	if got, want := ModPow(int64(1)<<62, 2, m), int64(big.NewInt(0).Mod(big.NewInt(0).Lsh(big.NewInt(1), 124), big.NewInt(m)).Int64()); got != want {
+		t.Errorf("ModPow(2^62, 2, MaxInt64) = %d, want %d", got, want)
+	}
+}
+
+func TestCRT(t *testing.T) {
+	tests := []struct {
+		name             string
+		residues, moduli []int64
+		x, m             int64
+		wantErr          error
+	}{
+		{name: "coprime", residues: []int64{2, 3, 2}, moduli: []int64{3, 5, 7}, x: 23, m: 105},
+		{name: "negative residue", residues: []int64{-1, 0}, moduli: []int64{4, 3}, x: 3, m: 12},
+		{name: "non-coprime", residues: []int64{3, 5}, moduli: []int64{4, 6}, x: 11, m: 12},
+		{name: "inconsistent", residues: []int64{1, 2}, moduli: []int64{4, 6}, wantErr: ErrNoSolution},
+		{name: "modulus overflows", residues: []int64{7, 7, 7}, moduli: []int64{1 << 30, 1<<31 - 1, 1<<32 - 5}, x: 7, wantErr: ErrModulusOverflow},
+		{name: "modulus overflows inconsistent", residues: []int64{1, 0, 0}, moduli: []int64{1 << 40, 1<<40 + 1, 2}, wantErr: ErrNoSolution},
+		{name: "solution overflows", residues: []int64{1, 0, 0}, moduli: []int64{1 << 30, 1<<31 - 1, 1<<32 - 5}, wantErr: ErrOverflow},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			x, m, err := CRT(test.residues, test.moduli)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("CRT() error = %v, want %v", err, test.wantErr)
+			}
+			if errors.Is(test.wantErr, ErrModulusOverflow) {
+				if x != test.x {
+					t.Errorf("CRT() x = %d, want %d", x, test.x)
+				}
+				if !errors.Is(err, ErrOverflow) {
+					t.Errorf("CRT() error = %v, want it to wrap %v too", err, ErrOverflow)
+				}
+				return
+			}
+			if err == nil && (x != test.x || m != test.m) {
+				t.Errorf("CRT() = %d, %d, want %d, %d", x, m, test.x, test.m)
+			}
+
+			if test.wantErr == ErrOverflow {
+				return
+			}
+			var br, bm []*big.Int
+			for i := range test.moduli {
+				br = append(br, big.NewInt(test.residues[i]))
+				bm = append(bm, big.NewInt(test.moduli[i]))
+			}
+			bx, bmod, err := CRTBig(br, bm)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("CRTBig() error = %v, want %v", err, test.wantErr)
+			}
+			if err == nil && (bx.Int64() != test.x || bmod.Int64() != test.m) {
+				t.Errorf("CRTBig() = %v, %v, want %d, %d", bx, bmod, test.x, test.m)
+			}
+		})
+	}
+}
+
+func TestPrimes(t *testing.T) {
+	if got, want := Primes(30), []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29}; !slices.Equal(got, want) {
+		t.Errorf("Primes(30) = %v, want %v", got, want)
+	}
+
+	for _, n := range []int{-3, 0, 1, 2, 9, 97, 7919} {
+		if got, want := IsPrime(n), n >= 0 && Sieve(7919)[n]; got != want {
+			t.Errorf("IsPrime(%d) = %v, want %v", n, got, want)
+		}
+	}
+
+	if got, want := Factorize(360), []PrimePower[int]{{2, 3}, {3, 2}, {5, 1}}; !slices.Equal(got, want) {
+		t.Errorf("Factorize(360) = %v, want %v", got, want)
+	}
+	if got, want := Factorize(int64(2*999983)), []PrimePower[int64]{{2, 1}, {999983, 1}}; !slices.Equal(got, want) {
+		t.Errorf("Factorize(2*999983) = %v, want %v", got, want)
+	}
+
+	if got, want := Divisors(36), []int{1, 2, 3, 4, 6, 9, 12, 18, 36}; !slices.Equal(got, want) {
+		t.Errorf("Divisors(36) = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,102 @@
+package mathx
+
+import (
+	"slices"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Sieve returns a slice of length n+1 in which element i is whether or not i
+// is prime, using the Sieve of Eratosthenes.
+func Sieve(n int) []bool {
+	if n < 0 {
+		return nil
+	}
+
+	ret := make([]bool, n+1)
+	for i := 2; i <= n; i++ {
+		ret[i] = true
+	}
+	for i := 2; i*i <= n; i++ {
+		if !ret[i] {
+			continue
+		}
+		for j := i * i; j <= n; j += i {
+			ret[j] = false
+		}
+	}
+	return ret
+}
+
+// Primes returns all primes less than or equal to |n|, in increasing order.
+func Primes(n int) []int {
+	var ret []int
+	for i, p := range Sieve(n) {
+		if p {
+			ret = append(ret, i)
+		}
+	}
+	return ret
+}
+
+// IsPrime returns whether or not |n| is prime, by trial division.
+func IsPrime[T constraints.Integer](n T) bool {
+	if n < 2 {
+		return false
+	}
+	for i := T(2); i <= n/i; i++ {
+		if n%i == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// PrimePower is a prime factor and the number of times it occurs.
+type PrimePower[T constraints.Integer] struct {
+	Prime T
+	Exp   int
+}
+
+// Factorize returns the prime factorization of |n|, in increasing order of
+// prime, by trial division. Numbers less than 2 have no prime factors.
+func Factorize[T constraints.Integer](n T) []PrimePower[T] {
+	var ret []PrimePower[T]
+	for p := T(2); p <= n/p; p++ {
+		if n%p != 0 {
+			continue
+		}
+		pp := PrimePower[T]{Prime: p}
+		for n%p == 0 {
+			n /= p
+			pp.Exp++
+		}
+		ret = append(ret, pp)
+	}
+	if n > 1 {
+		ret = append(ret, PrimePower[T]{Prime: n, Exp: 1})
+	}
+	return ret
+}
+
+// Divisors returns all positive divisors of |n|, in increasing order. It
+// returns nil if |n| isn't positive.
+func Divisors[T constraints.Integer](n T) []T {
+	if n <= 0 {
+		return nil
+	}
+
+	ret := []T{1}
+	for _, pp := range Factorize(n) {
+		cur := len(ret)
+		mult := T(1)
+		for e := 0; e < pp.Exp; e++ {
+			mult *= pp.Prime
+			for _, d := range ret[:cur] {
+				ret = append(ret, d*mult)
+			}
+		}
+	}
+	slices.Sort(ret)
+	return ret
+}
@@ -0,0 +1,56 @@
+package must
+
+import (
+	"io"
+
+	"github.com/glennhartmann/aoclib/parse"
+)
+
+// GetInputBlocks reads all lines of the current input Source (stdin by
+// default) and splits them into blocks separated by blank lines. Runs of
+// several blank lines are treated as a single separator, so no block is ever
+// empty.
+func GetInputBlocks() [][]string {
+	return check(parse.GetInputBlocks())
+}
+
+// GetInputBlocksFrom is like GetInputBlocks, but reads from |r|.
+func GetInputBlocksFrom(r io.Reader) [][]string {
+	return check(parse.GetInputBlocksFrom(r))
+}
+
+// SplitBlocks splits |lines| into blocks separated by blank lines, like
+// GetInputBlocks.
+func SplitBlocks(lines []string) [][]string {
+	return parse.SplitBlocks(lines)
+}
+
+// ForEachBlockOfStreamedInput calls |f| for each block of the current input
+// Source (stdin by default), where blocks are separated by blank lines as in
+// GetInputBlocks. Only one block is held in memory at a time.
+func ForEachBlockOfStreamedInput(f func(blockNum int, block []string)) {
+	checkErr(parse.ForEachBlockOfStreamedInput(noErr2(f)))
+}
+
+// ForEachBlockOfStreamedInputFrom is like ForEachBlockOfStreamedInput, but
+// reads from |r|.
+func ForEachBlockOfStreamedInputFrom(r io.Reader, f func(blockNum int, block []string)) {
+	checkErr(parse.ForEachBlockOfStreamedInputFrom(r, noErr2(f)))
+}
+
+// SplitBlockHeader splits |block| into its first |n| lines and the rest. It
+// panics if the block has fewer than |n| lines.
+func SplitBlockHeader(block []string, n int) (header, rest []string) {
+	header, rest, err := parse.SplitBlockHeader(block, n)
+	checkErr(err)
+	return header, rest
+}
+
+// BlockHeaderAndGrid splits |block| into a single header line and a grid made
+// up of the remaining lines, as in inputs like "Tile 1234:" followed by a
+// picture. It panics if the block is empty.
+func BlockHeaderAndGrid(block []string) (header string, grid [][]byte) {
+	header, grid, err := parse.BlockHeaderAndGrid(block)
+	checkErr(err)
+	return header, grid
+}
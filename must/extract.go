@@ -0,0 +1,69 @@
+package must
+
+import (
+	"github.com/glennhartmann/aoclib/parse"
+)
+
+// ExtractInts returns every integer in |s|, in order, ignoring everything
+// else. A '-' immediately before a number makes it negative, so be careful
+// with ranges like "3-5", which give [3, -5].
+func ExtractInts(s string) []int {
+	return check(parse.ExtractInts(s))
+}
+
+// ExtractInts64 is like ExtractInts, but for int64s.
+func ExtractInts64(s string) []int64 {
+	return check(parse.ExtractInts64(s))
+}
+
+// ExtractUints is like ExtractInts, but ignores signs, so "3-5" gives [3, 5].
+func ExtractUints(s string) []uint {
+	return check(parse.ExtractUints(s))
+}
+
+// ExtractFloats is like ExtractInts, but also picks up decimal points and
+// exponents.
+func ExtractFloats(s string) []float64 {
+	return check(parse.ExtractFloats(s))
+}
+
+// ExtractIntsFromLines calls ExtractInts on each of |lines|.
+func ExtractIntsFromLines(lines []string) [][]int {
+	return check(parse.ExtractIntsFromLines(lines))
+}
+
+// ExtractInts64FromLines calls ExtractInts64 on each of |lines|.
+func ExtractInts64FromLines(lines []string) [][]int64 {
+	return check(parse.ExtractInts64FromLines(lines))
+}
+
+// ExtractUintsFromLines calls ExtractUints on each of |lines|.
+func ExtractUintsFromLines(lines []string) [][]uint {
+	return check(parse.ExtractUintsFromLines(lines))
+}
+
+// ExtractFloatsFromLines calls ExtractFloats on each of |lines|.
+func ExtractFloatsFromLines(lines []string) [][]float64 {
+	return check(parse.ExtractFloatsFromLines(lines))
+}
+
+// ExtractAllInts returns every integer in all of |lines|, in order, as a
+// single slice.
+func ExtractAllInts(lines []string) []int {
+	return check(parse.ExtractAllInts(lines))
+}
+
+// ExtractAllInts64 is like ExtractAllInts, but for int64s.
+func ExtractAllInts64(lines []string) []int64 {
+	return check(parse.ExtractAllInts64(lines))
+}
+
+// ExtractAllUints is like ExtractAllInts, but for uints.
+func ExtractAllUints(lines []string) []uint {
+	return check(parse.ExtractAllUints(lines))
+}
+
+// ExtractAllFloats is like ExtractAllInts, but for float64s.
+func ExtractAllFloats(lines []string) []float64 {
+	return check(parse.ExtractAllFloats(lines))
+}
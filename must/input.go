@@ -0,0 +1,28 @@
+package must
+
+import (
+	"github.com/glennhartmann/aoclib/parse"
+)
+
+// Source is somewhere that puzzle input can be read from. It's shared with
+// package parse, so changing the input in one package changes it in both.
+type Source = parse.Source
+
+// StdinSource returns a Source that reads from stdin. This is the default.
+func StdinSource() Source { return parse.StdinSource() }
+
+// FileSource returns a Source that reads from the file at |path|.
+func FileSource(path string) Source { return parse.FileSource(path) }
+
+// StringSource returns a Source that reads from |s|. This is useful for
+// running against example inputs embedded in code or tests.
+func StringSource(s string) Source { return parse.StringSource(s) }
+
+// SetInput changes the Source used by GetFullInput, GetFullInputAsBytes and
+// ForEachLineOfStreamedInput. It returns the previous Source, so that it can
+// be restored.
+func SetInput(src Source) Source { return parse.SetInput(src) }
+
+// Input returns the Source currently used by GetFullInput,
+// GetFullInputAsBytes and ForEachLineOfStreamedInput.
+func Input() Source { return parse.Input() }
@@ -1,102 +1,107 @@
-// Package must contains helper functions that panic if they have errors.
+// Package must contains helper functions that panic if they have errors. Most
+// of them are thin wrappers around the equivalent functions in package parse.
 package must
 
 import (
-	"bufio"
 	"encoding/json"
 	"io"
-	"log"
-	"os"
 	"regexp"
-	"strconv"
-	"strings"
 
 	"github.com/glennhartmann/aoclib/common"
+	"github.com/glennhartmann/aoclib/parse"
 )
 
-func Atoi(s string) int {
-	i, err := strconv.Atoi(s)
+// check panics with |err| if it's non-nil, and otherwise returns |v|.
+func check[T any](v T, err error) T {
+	checkErr(err)
+	return v
+}
+
+// checkErr panics with |err| if it's non-nil.
+func checkErr(err error) {
 	if err != nil {
-		common.Panicf("invalid int for Atoi: %s (%v)", s, err)
+		common.Panicf("%v", err)
 	}
-	return i
+}
+
+func Atoi(s string) int {
+	return check(parse.Atoi(s))
 }
 
 func Atoi64(s string) int64 {
-	i, err := strconv.ParseInt(s, 10, 64)
-	if err != nil {
-		common.Panicf("invalid int64 for Atoi64: %s (%v)", s, err)
-	}
-	return i
+	return check(parse.Atoi64(s))
+}
+
+func Atou(s string) uint {
+	return check(parse.Atou(s))
 }
 
+func Atof(s string) float64 {
+	return check(parse.Atof(s))
+}
+
+// ForEachLineOfStreamedInput calls |f| for each line of the current input
+// Source (stdin by default), without reading it all into memory first. Each
+// line is logged at debug level to common.Logger().
 func ForEachLineOfStreamedInput(f func(lineNum int, s string)) {
-	r := bufio.NewReader(os.Stdin)
-	lineNum := 0
-	for {
-		s, err := r.ReadString('\n')
-		if err == io.EOF {
-			log.Printf("EOF")
-			break
-		}
-		if err != nil {
-			common.Panicf("unable to read from stdin: %v", err)
-		}
-		s = strings.TrimSuffix(s, "\n")
-		log.Printf("current line: %q", s)
-
-		f(lineNum, s)
-
-		lineNum++
-	}
+	checkErr(parse.ForEachLineOfStreamedInput(noErr2(f)))
 }
 
-func GetFullInput() []string {
-	input, err := io.ReadAll(os.Stdin)
-	if err != nil {
-		common.Panicf("error reading from stdin: %v", err)
+// ForEachLineOfStreamedInputFrom is like ForEachLineOfStreamedInput, but reads
+// from |r|.
+func ForEachLineOfStreamedInputFrom(r io.Reader, f func(lineNum int, s string)) {
+	checkErr(parse.ForEachLineOfStreamedInputFrom(r, noErr2(f)))
+}
+
+// noErr2 adapts |f| to the callback signature used by package parse.
+func noErr2[A, B any](f func(A, B)) func(A, B) error {
+	return func(a A, b B) error {
+		f(a, b)
+		return nil
 	}
+}
 
-	inputStr := string(input)
-	lines := strings.Split(inputStr, "\n")
+// GetFullInput reads all lines of the current input Source (stdin by
+// default). A trailing newline doesn't produce an extra empty line.
+func GetFullInput() []string {
+	return check(parse.GetFullInput())
+}
 
-	if lines[len(lines)-1] == "" {
-		lines = lines[:len(lines)-1]
-	}
+// GetFullInputFrom is like GetFullInput, but reads from |r|.
+func GetFullInputFrom(r io.Reader) []string {
+	return check(parse.GetFullInputFrom(r))
+}
 
-	return lines
+// ReadFile is like GetFullInput, but reads from the file at |path|.
+func ReadFile(path string) []string {
+	return check(parse.ReadFile(path))
 }
 
 func GetFullInputAsBytes() [][]byte {
-	return common.StringSliceToByteSlice2(GetFullInput())
+	return check(parse.GetFullInputAsBytes())
 }
 
-func FindStringSubmatch(rx *regexp.Regexp, s string, expectedLen int) []string {
-	m := rx.FindStringSubmatch(s)
-	if len(m) != expectedLen {
-		common.Panicf("regexp match returned len %d, wanted %d", len(m), expectedLen)
-	}
-	return m
+// GetFullInputAsBytesFrom is like GetFullInputAsBytes, but reads from |r|.
+func GetFullInputAsBytesFrom(r io.Reader) [][]byte {
+	return check(parse.GetFullInputAsBytesFrom(r))
 }
 
-func parseListOfNumbersBase[T any](s, sep string, atoi func(string) T) []T {
-	sp := strings.Split(s, sep)
-	ret := make([]T, 0, len(sp))
-	for _, i := range sp {
-		if i == "" {
-			continue
-		}
-		ret = append(ret, atoi(strings.TrimSpace(i)))
-	}
-	return ret
+// ReadFileAsBytes is like GetFullInputAsBytes, but reads from the file at
+// |path|.
+func ReadFileAsBytes(path string) [][]byte {
+	return check(parse.ReadFileAsBytes(path))
+}
+
+func FindStringSubmatch(rx *regexp.Regexp, s string, expectedLen int) []string {
+	return check(parse.FindStringSubmatch(rx, s, expectedLen))
 }
 
 func ParseListOfNumbers(s, sep string) []int {
-	return parseListOfNumbersBase(s, sep, Atoi)
+	return check(parse.ParseListOfNumbers(s, sep))
 }
 
 func ParseListOfNumbers64(s, sep string) []int64 {
-	return parseListOfNumbersBase(s, sep, Atoi64)
+	return check(parse.ParseListOfNumbers64(s, sep))
 }
 
 func JSONMarshal(v any) []byte {
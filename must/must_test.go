@@ -0,0 +1,204 @@
+package must
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/glennhartmann/aoclib/grid/d4"
+)
+
+func TestGetFullInputFrom(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "trailing newline",
+			input: "abc\ndef\n",
+			want:  []string{"abc", "def"},
+		},
+		{
+			name:  "no trailing newline",
+			input: "abc\ndef",
+			want:  []string{"abc", "def"},
+		},
+		{
+			name:  "blank lines preserved",
+			input: "abc\n\ndef\n",
+			want:  []string{"abc", "", "def"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := GetFullInputFrom(strings.NewReader(test.input)); !slices.Equal(got, test.want) {
+				t.Errorf("GetFullInputFrom(%q) = %q, want %q", test.input, got, test.want)
+			}
+
+			var got []string
+			ForEachLineOfStreamedInputFrom(strings.NewReader(test.input), func(lineNum int, s string) {
+				if lineNum != len(got) {
+					t.Errorf("lineNum = %d, want %d", lineNum, len(got))
+				}
+				got = append(got, s)
+			})
+			if !slices.Equal(got, test.want) {
+				t.Errorf("ForEachLineOfStreamedInputFrom(%q) lines = %q, want %q", test.input, got, test.want)
+			}
+		})
+	}
+}
+
+func TestSetInput(t *testing.T) {
+	prev := SetInput(StringSource("12\n34\n"))
+	defer SetInput(prev)
+
+	if got, want := GetFullInput(), []string{"12", "34"}; !slices.Equal(got, want) {
+		t.Errorf("GetFullInput() = %q, want %q", got, want)
+	}
+
+	// each call re-opens the source
+	if got, want := GetFullInputAsBytes(), [][]byte{[]byte("12"), []byte("34")}; !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("GetFullInputAsBytes() = %q, want %q", got, want)
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte("x\ny\nz\n"), 0o644); err != nil {
+		t.Fatalf("os.WriteFile() = %v", err)
+	}
+
+	if got, want := ReadFile(path), []string{"x", "y", "z"}; !slices.Equal(got, want) {
+		t.Errorf("ReadFile() = %q, want %q", got, want)
+	}
+
+	prev := SetInput(FileSource(path))
+	defer SetInput(prev)
+
+	var got []string
+	ForEachLineOfStreamedInput(func(_ int, s string) { got = append(got, s) })
+	if want := []string{"x", "y", "z"}; !slices.Equal(got, want) {
+		t.Errorf("ForEachLineOfStreamedInput() lines = %q, want %q", got, want)
+	}
+}
+
+func TestBlocks(t *testing.T) {
+	const input = "Tile 1:\n#.\n.#\n\n\nTile 2:\n..\n##\n\n"
+	want := [][]string{
+		{"Tile 1:", "#.", ".#"},
+		{"Tile 2:", "..", "##"},
+	}
+
+	if got := GetInputBlocksFrom(strings.NewReader(input)); !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("GetInputBlocksFrom() = %q, want %q", got, want)
+	}
+
+	var got [][]string
+	ForEachBlockOfStreamedInputFrom(strings.NewReader(input), func(blockNum int, block []string) {
+		if blockNum != len(got) {
+			t.Errorf("blockNum = %d, want %d", blockNum, len(got))
+		}
+		got = append(got, block)
+	})
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("ForEachBlockOfStreamedInputFrom() blocks = %q, want %q", got, want)
+	}
+
+	header, grid := BlockHeaderAndGrid(want[1])
+	if header != "Tile 2:" || len(grid) != 2 || string(grid[1]) != "##" {
+		t.Errorf("BlockHeaderAndGrid() = %q, %q, want \"Tile 2:\", [.. ##]", header, grid)
+	}
+}
+
+func TestExtract(t *testing.T) {
+	const line = "Sensor at x=2, y=-18: closest beacon is at x=-2, y=15"
+
+	if got, want := ExtractInts(line), []int{2, -18, -2, 15}; !slices.Equal(got, want) {
+		t.Errorf("ExtractInts(%q) = %v, want %v", line, got, want)
+	}
+	if got, want := ExtractInts64(line), []int64{2, -18, -2, 15}; !slices.Equal(got, want) {
+		t.Errorf("ExtractInts64(%q) = %v, want %v", line, got, want)
+	}
+	if got, want := ExtractUints("1-3 a: 12,7"), []uint{1, 3, 12, 7}; !slices.Equal(got, want) {
+		t.Errorf("ExtractUints() = %v, want %v", got, want)
+	}
+	if got, want := ExtractFloats("v=-1.5,2e3 and 7."), []float64{-1.5, 2000, 7}; !slices.Equal(got, want) {
+		t.Errorf("ExtractFloats() = %v, want %v", got, want)
+	}
+	if got := ExtractInts("no numbers here"); len(got) != 0 {
+		t.Errorf("ExtractInts() = %v, want []", got)
+	}
+
+	lines := []string{"1 2", "", "-3"}
+	if got, want := ExtractIntsFromLines(lines), [][]int{{1, 2}, {}, {-3}}; !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("ExtractIntsFromLines() = %v, want %v", got, want)
+	}
+	if got, want := ExtractAllInts(lines), []int{1, 2, -3}; !slices.Equal(got, want) {
+		t.Errorf("ExtractAllInts() = %v, want %v", got, want)
+	}
+}
+
+func TestParse(t *testing.T) {
+	type move struct {
+		Dir   d4.Direction
+		Steps int
+		Color string `aoc:"colour"`
+		Label byte   `aoc:",char"`
+		Skip  int    `aoc:"-"`
+		other int
+	}
+
+	rx := regexp.MustCompile(`^(?P<dir>[UDLR]) (?P<steps>\d+) \(#(?P<colour>[0-9a-f]+)\)(?: (?P<label>.))?$`)
+
+	got := ParseLines[move](rx, []string{
+		"R 6 (#70c710)",
+		"U 12 (#caa173) x",
+	})
+	want := []move{
+		{Dir: d4.Right, Steps: 6, Color: "70c710"},
+		{Dir: d4.Up, Steps: 12, Color: "caa173", Label: 'x'},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("ParseLines() = %+v, want %+v", got, want)
+	}
+
+	type point struct {
+		X, Y float64
+	}
+	if got, want := Parse[point](regexp.MustCompile(`x=(?P<x>\S+), y=(?P<y>\S+)`), "x=1.5, y=-2"), (point{1.5, -2}); got != want {
+		t.Errorf("Parse() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParsePanics(t *testing.T) {
+	type sensor struct {
+		X int
+	}
+
+	tests := []struct {
+		name string
+		rx   string
+		line string
+	}{
+		{name: "no match", rx: `x=(?P<x>\d+)`, line: "y=5"},
+		{name: "unknown group", rx: `x=(?P<x>\d+) (?P<z>\d+)`, line: "x=5 6"},
+		{name: "bad int", rx: `x=(?P<x>\S+)`, line: "x=five"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Parse(%q, %q) didn't panic", test.rx, test.line)
+				}
+			}()
+			Parse[sensor](regexp.MustCompile(test.rx), test.line)
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package must
+
+import (
+	"regexp"
+
+	"github.com/glennhartmann/aoclib/parse"
+)
+
+// Parse matches |line| against |rx|, and returns a T (which must be a struct)
+// with its fields filled in from rx's named capture groups. See parse.Parse
+// for how fields are matched up with groups and which types are supported.
+//
+// Parse panics with a descriptive message if |line| doesn't match, if a
+// named group or tagged field has nothing to pair up with, if two fields want
+// the same group, or if a value can't be converted.
+func Parse[T any](rx *regexp.Regexp, line string) T {
+	return check(parse.Parse[T](rx, line))
+}
+
+// ParseLines calls Parse on each of |lines|.
+func ParseLines[T any](rx *regexp.Regexp, lines []string) []T {
+	return check(parse.ParseLines[T](rx, lines))
+}
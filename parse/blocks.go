@@ -0,0 +1,107 @@
+package parse
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/glennhartmann/aoclib/common"
+)
+
+// GetInputBlocks reads all lines of the current input Source (stdin by
+// default) and splits them into blocks separated by blank lines. Runs of
+// several blank lines are treated as a single separator, so no block is ever
+// empty.
+func GetInputBlocks() ([][]string, error) {
+	lines, err := GetFullInput()
+	if err != nil {
+		return nil, err
+	}
+	return SplitBlocks(lines), nil
+}
+
+// GetInputBlocksFrom is like GetInputBlocks, but reads from |r|.
+func GetInputBlocksFrom(r io.Reader) ([][]string, error) {
+	lines, err := GetFullInputFrom(r)
+	if err != nil {
+		return nil, err
+	}
+	return SplitBlocks(lines), nil
+}
+
+// SplitBlocks splits |lines| into blocks separated by blank lines, like
+// GetInputBlocks.
+func SplitBlocks(lines []string) [][]string {
+	var ret [][]string
+	forEachBlock(lines, func(_ int, block []string) { ret = append(ret, block) })
+	return ret
+}
+
+func forEachBlock(lines []string, f func(blockNum int, block []string)) {
+	blockNum, start := 0, 0
+	for i := 0; i <= len(lines); i++ {
+		if i < len(lines) && lines[i] != "" {
+			continue
+		}
+		if i > start {
+			f(blockNum, lines[start:i:i])
+			blockNum++
+		}
+		start = i + 1
+	}
+}
+
+// ForEachBlockOfStreamedInput calls |f| for each block of the current input
+// Source (stdin by default), where blocks are separated by blank lines as in
+// GetInputBlocks. Only one block is held in memory at a time. If |f| returns
+// an error, iteration stops and the error is returned.
+func ForEachBlockOfStreamedInput(f func(blockNum int, block []string) error) error {
+	return withInput(func(r io.Reader) error { return ForEachBlockOfStreamedInputFrom(r, f) })
+}
+
+// ForEachBlockOfStreamedInputFrom is like ForEachBlockOfStreamedInput, but
+// reads from |r|.
+func ForEachBlockOfStreamedInputFrom(r io.Reader, f func(blockNum int, block []string) error) error {
+	blockNum := 0
+	var block []string
+	flush := func() error {
+		if len(block) == 0 {
+			return nil
+		}
+		err := f(blockNum, block)
+		blockNum++
+		block = nil
+		return err
+	}
+
+	err := ForEachLineOfStreamedInputFrom(r, func(_ int, s string) error {
+		if s == "" {
+			return flush()
+		}
+		block = append(block, s)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return flush()
+}
+
+// SplitBlockHeader splits |block| into its first |n| lines and the rest. It
+// returns an error if the block has fewer than |n| lines.
+func SplitBlockHeader(block []string, n int) (header, rest []string, err error) {
+	if len(block) < n {
+		return nil, nil, fmt.Errorf("block has %d lines, wanted at least %d for the header", len(block), n)
+	}
+	return block[:n:n], block[n:], nil
+}
+
+// BlockHeaderAndGrid splits |block| into a single header line and a grid made
+// up of the remaining lines, as in inputs like "Tile 1234:" followed by a
+// picture. It returns an error if the block is empty.
+func BlockHeaderAndGrid(block []string) (header string, grid [][]byte, err error) {
+	h, rest, err := SplitBlockHeader(block, 1)
+	if err != nil {
+		return "", nil, err
+	}
+	return h[0], common.StringSliceToByteSlice2(rest), nil
+}
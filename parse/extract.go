@@ -0,0 +1,113 @@
+package parse
+
+import (
+	"regexp"
+)
+
+var (
+	intRx   = regexp.MustCompile(`-?\d+`)
+	uintRx  = regexp.MustCompile(`\d+`)
+	floatRx = regexp.MustCompile(`-?\d+(?:\.\d+)?(?:[eE][-+]?\d+)?`)
+)
+
+// ExtractInts returns every integer in |s|, in order, ignoring everything
+// else. A '-' immediately before a number makes it negative, so be careful
+// with ranges like "3-5", which give [3, -5]. The only possible error is a
+// number that's too big for an int.
+func ExtractInts(s string) ([]int, error) {
+	return extract(intRx, s, Atoi)
+}
+
+// ExtractInts64 is like ExtractInts, but for int64s.
+func ExtractInts64(s string) ([]int64, error) {
+	return extract(intRx, s, Atoi64)
+}
+
+// ExtractUints is like ExtractInts, but ignores signs, so "3-5" gives [3, 5].
+func ExtractUints(s string) ([]uint, error) {
+	return extract(uintRx, s, Atou)
+}
+
+// ExtractFloats is like ExtractInts, but also picks up decimal points and
+// exponents.
+func ExtractFloats(s string) ([]float64, error) {
+	return extract(floatRx, s, Atof)
+}
+
+// ExtractIntsFromLines calls ExtractInts on each of |lines|.
+func ExtractIntsFromLines(lines []string) ([][]int, error) {
+	return extractFromLines(lines, ExtractInts)
+}
+
+// ExtractInts64FromLines calls ExtractInts64 on each of |lines|.
+func ExtractInts64FromLines(lines []string) ([][]int64, error) {
+	return extractFromLines(lines, ExtractInts64)
+}
+
+// ExtractUintsFromLines calls ExtractUints on each of |lines|.
+func ExtractUintsFromLines(lines []string) ([][]uint, error) {
+	return extractFromLines(lines, ExtractUints)
+}
+
+// ExtractFloatsFromLines calls ExtractFloats on each of |lines|.
+func ExtractFloatsFromLines(lines []string) ([][]float64, error) {
+	return extractFromLines(lines, ExtractFloats)
+}
+
+// ExtractAllInts returns every integer in all of |lines|, in order, as a
+// single slice.
+func ExtractAllInts(lines []string) ([]int, error) {
+	return extractAll(lines, ExtractInts)
+}
+
+// ExtractAllInts64 is like ExtractAllInts, but for int64s.
+func ExtractAllInts64(lines []string) ([]int64, error) {
+	return extractAll(lines, ExtractInts64)
+}
+
+// ExtractAllUints is like ExtractAllInts, but for uints.
+func ExtractAllUints(lines []string) ([]uint, error) {
+	return extractAll(lines, ExtractUints)
+}
+
+// ExtractAllFloats is like ExtractAllInts, but for float64s.
+func ExtractAllFloats(lines []string) ([]float64, error) {
+	return extractAll(lines, ExtractFloats)
+}
+
+func extract[T any](rx *regexp.Regexp, s string, atoi func(string) (T, error)) ([]T, error) {
+	m := rx.FindAllString(s, -1)
+	ret := make([]T, 0, len(m))
+	for _, i := range m {
+		n, err := atoi(i)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, n)
+	}
+	return ret, nil
+}
+
+func extractFromLines[T any](lines []string, f func(string) ([]T, error)) ([][]T, error) {
+	ret := make([][]T, 0, len(lines))
+	for _, line := range lines {
+		v, err := f(line)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, v)
+	}
+	return ret, nil
+}
+
+func extractAll[T any](lines []string, f func(string) ([]T, error)) ([]T, error) {
+	var ret []T
+	for _, line := range lines {
+		v, err := f(line)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, v...)
+	}
+	return ret, nil
+}
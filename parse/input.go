@@ -0,0 +1,77 @@
+package parse
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Source is somewhere that puzzle input can be read from.
+type Source interface {
+	// Open returns a reader for the input. The caller must close it.
+	Open() (io.ReadCloser, error)
+}
+
+type stdinSource struct{}
+
+func (stdinSource) Open() (io.ReadCloser, error) { return io.NopCloser(os.Stdin), nil }
+
+type fileSource string
+
+func (f fileSource) Open() (io.ReadCloser, error) { return os.Open(string(f)) }
+
+type stringSource string
+
+func (s stringSource) Open() (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader(string(s))), nil
+}
+
+// StdinSource returns a Source that reads from stdin. This is the default.
+func StdinSource() Source { return stdinSource{} }
+
+// FileSource returns a Source that reads from the file at |path|.
+func FileSource(path string) Source { return fileSource(path) }
+
+// StringSource returns a Source that reads from |s|. This is useful for
+// running against example inputs embedded in code or tests.
+func StringSource(s string) Source { return stringSource(s) }
+
+var (
+	inputMu sync.Mutex
+	input   Source = StdinSource()
+)
+
+// SetInput changes the Source used by GetFullInput, GetFullInputAsBytes and
+// ForEachLineOfStreamedInput (and their equivalents in package must). It
+// returns the previous Source, so that it can be restored.
+func SetInput(src Source) Source {
+	inputMu.Lock()
+	defer inputMu.Unlock()
+	prev := input
+	input = src
+	return prev
+}
+
+// Input returns the Source currently used by GetFullInput,
+// GetFullInputAsBytes and ForEachLineOfStreamedInput.
+func Input() Source {
+	inputMu.Lock()
+	defer inputMu.Unlock()
+	return input
+}
+
+// withInput calls |f| with a reader for the current input Source.
+func withInput(f func(r io.Reader) error) error {
+	return withSource(Input(), f)
+}
+
+func withSource(src Source, f func(r io.Reader) error) error {
+	r, err := src.Open()
+	if err != nil {
+		return fmt.Errorf("unable to open input: %w", err)
+	}
+	defer r.Close()
+	return f(r)
+}
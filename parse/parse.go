@@ -0,0 +1,172 @@
+// Package parse contains helper functions for reading and parsing puzzle
+// input that return errors instead of panicking. Package must wraps each of
+// them with a version that panics.
+package parse
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/glennhartmann/aoclib/common"
+)
+
+func Atoi(s string) (int, error) {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid int for Atoi: %s (%w)", s, err)
+	}
+	return i, nil
+}
+
+func Atoi64(s string) (int64, error) {
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid int64 for Atoi64: %s (%w)", s, err)
+	}
+	return i, nil
+}
+
+func Atou(s string) (uint, error) {
+	i, err := strconv.ParseUint(s, 10, 0)
+	if err != nil {
+		return 0, fmt.Errorf("invalid uint for Atou: %s (%w)", s, err)
+	}
+	return uint(i), nil
+}
+
+func Atof(s string) (float64, error) {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid float64 for Atof: %s (%w)", s, err)
+	}
+	return f, nil
+}
+
+// ForEachLineOfStreamedInput calls |f| for each line of the current input
+// Source (stdin by default), without reading it all into memory first. Each
+// line is logged at debug level to common.Logger(). If |f| returns an error,
+// iteration stops and the error is returned.
+func ForEachLineOfStreamedInput(f func(lineNum int, s string) error) error {
+	return withInput(func(r io.Reader) error { return ForEachLineOfStreamedInputFrom(r, f) })
+}
+
+// ForEachLineOfStreamedInputFrom is like ForEachLineOfStreamedInput, but reads
+// from |r|.
+func ForEachLineOfStreamedInputFrom(r io.Reader, f func(lineNum int, s string) error) error {
+	br := bufio.NewReader(r)
+	lineNum := 0
+	for {
+		s, err := br.ReadString('\n')
+		if err == io.EOF && s == "" {
+			common.Logger().Debug("EOF")
+			return nil
+		}
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("unable to read input: %w", err)
+		}
+		s = strings.TrimSuffix(s, "\n")
+		common.Logger().Debug("current line", "lineNum", lineNum, "line", s)
+
+		if err := f(lineNum, s); err != nil {
+			return err
+		}
+
+		lineNum++
+	}
+}
+
+// GetFullInput reads all lines of the current input Source (stdin by
+// default). A trailing newline doesn't produce an extra empty line.
+func GetFullInput() ([]string, error) {
+	var lines []string
+	err := withInput(func(r io.Reader) (err error) {
+		lines, err = GetFullInputFrom(r)
+		return err
+	})
+	return lines, err
+}
+
+// GetFullInputFrom is like GetFullInput, but reads from |r|.
+func GetFullInputFrom(r io.Reader) ([]string, error) {
+	input, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading input: %w", err)
+	}
+
+	inputStr := string(input)
+	lines := strings.Split(inputStr, "\n")
+
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines, nil
+}
+
+// ReadFile is like GetFullInput, but reads from the file at |path|.
+func ReadFile(path string) ([]string, error) {
+	var lines []string
+	err := withSource(FileSource(path), func(r io.Reader) (err error) {
+		lines, err = GetFullInputFrom(r)
+		return err
+	})
+	return lines, err
+}
+
+func GetFullInputAsBytes() ([][]byte, error) {
+	return toBytes(GetFullInput())
+}
+
+// GetFullInputAsBytesFrom is like GetFullInputAsBytes, but reads from |r|.
+func GetFullInputAsBytesFrom(r io.Reader) ([][]byte, error) {
+	return toBytes(GetFullInputFrom(r))
+}
+
+// ReadFileAsBytes is like GetFullInputAsBytes, but reads from the file at
+// |path|.
+func ReadFileAsBytes(path string) ([][]byte, error) {
+	return toBytes(ReadFile(path))
+}
+
+func toBytes(lines []string, err error) ([][]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	return common.StringSliceToByteSlice2(lines), nil
+}
+
+func FindStringSubmatch(rx *regexp.Regexp, s string, expectedLen int) ([]string, error) {
+	m := rx.FindStringSubmatch(s)
+	if len(m) != expectedLen {
+		return nil, fmt.Errorf("regexp match returned len %d, wanted %d", len(m), expectedLen)
+	}
+	return m, nil
+}
+
+func parseListOfNumbersBase[T any](s, sep string, atoi func(string) (T, error)) ([]T, error) {
+	sp := strings.Split(s, sep)
+	ret := make([]T, 0, len(sp))
+	for _, i := range sp {
+		if i == "" {
+			continue
+		}
+		n, err := atoi(strings.TrimSpace(i))
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, n)
+	}
+	return ret, nil
+}
+
+func ParseListOfNumbers(s, sep string) ([]int, error) {
+	return parseListOfNumbersBase(s, sep, Atoi)
+}
+
+func ParseListOfNumbers64(s, sep string) ([]int64, error) {
+	return parseListOfNumbersBase(s, sep, Atoi64)
+}
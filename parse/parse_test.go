@@ -0,0 +1,199 @@
+package parse
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/glennhartmann/aoclib/grid/d4"
+)
+
+func TestAtoi(t *testing.T) {
+	if got, err := Atoi("-42"); err != nil || got != -42 {
+		t.Errorf("Atoi(\"-42\") = %d, %v, want -42, nil", got, err)
+	}
+
+	_, err := Atoi("x")
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("Atoi(\"x\") error = %v, want strconv.ErrSyntax", err)
+	}
+}
+
+func TestParseListOfNumbers(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    []int
+		wantErr bool
+	}{
+		{name: "good", s: "1, 2,3", want: []int{1, 2, 3}},
+		{name: "empty", s: "", want: []int{}},
+		{name: "bad", s: "1, two, 3", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseListOfNumbers(test.s, ",")
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ParseListOfNumbers(%q) error = %v, wantErr %v", test.s, err, test.wantErr)
+			}
+			if !slices.Equal(got, test.want) {
+				t.Errorf("ParseListOfNumbers(%q) = %v, want %v", test.s, got, test.want)
+			}
+		})
+	}
+}
+
+func TestFindStringSubmatch(t *testing.T) {
+	rx := regexp.MustCompile(`(\d+)-(\d+)`)
+	if _, err := FindStringSubmatch(rx, "3-5", 3); err != nil {
+		t.Errorf("FindStringSubmatch(\"3-5\") error = %v, want nil", err)
+	}
+	if _, err := FindStringSubmatch(rx, "nope", 3); err == nil {
+		t.Errorf("FindStringSubmatch(\"nope\") error = nil, want non-nil")
+	}
+}
+
+func TestReaders(t *testing.T) {
+	prev := SetInput(StringSource("a\nb\n\nc\n"))
+	defer SetInput(prev)
+
+	lines, err := GetFullInput()
+	if want := []string{"a", "b", "", "c"}; err != nil || !slices.Equal(lines, want) {
+		t.Errorf("GetFullInput() = %q, %v, want %q, nil", lines, err, want)
+	}
+
+	blocks, err := GetInputBlocks()
+	if err != nil || len(blocks) != 2 {
+		t.Errorf("GetInputBlocks() = %q, %v, want 2 blocks", blocks, err)
+	}
+
+	if _, err := ReadFile("/does/not/exist"); err == nil {
+		t.Errorf("ReadFile(missing) error = nil, want non-nil")
+	}
+}
+
+func TestForEachLineStopsOnError(t *testing.T) {
+	stop := errors.New("stop")
+	var seen []string
+	err := ForEachLineOfStreamedInputFrom(strings.NewReader("a\nb\nc"), func(_ int, s string) error {
+		seen = append(seen, s)
+		if s == "b" {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("ForEachLineOfStreamedInputFrom() error = %v, want %v", err, stop)
+	}
+	if want := []string{"a", "b"}; !slices.Equal(seen, want) {
+		t.Errorf("ForEachLineOfStreamedInputFrom() saw %q, want %q", seen, want)
+	}
+}
+
+func TestExtract(t *testing.T) {
+	got, err := ExtractAllInts([]string{"x=1, y=-2", "z=3"})
+	if want := []int{1, -2, 3}; err != nil || !slices.Equal(got, want) {
+		t.Errorf("ExtractAllInts() = %v, %v, want %v, nil", got, err, want)
+	}
+
+	if _, err := ExtractInts("99999999999999999999"); !errors.Is(err, strconv.ErrRange) {
+		t.Errorf("ExtractInts(huge) error = %v, want strconv.ErrRange", err)
+	}
+}
+
+func TestParse(t *testing.T) {
+	type move struct {
+		Dir   d4.Direction
+		Steps int
+	}
+	rx := regexp.MustCompile(`^(?P<dir>\S) (?P<steps>\S+)$`)
+
+	tests := []struct {
+		name    string
+		line    string
+		want    move
+		wantErr bool
+	}{
+		{name: "good", line: "L 4", want: move{d4.Left, 4}},
+		{name: "no match", line: "L", wantErr: true},
+		{name: "bad direction", line: "X 4", wantErr: true},
+		{name: "bad int", line: "L four", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Parse[move](rx, test.line)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Parse(%q) error = %v, wantErr %v", test.line, err, test.wantErr)
+			}
+			if !test.wantErr && got != test.want {
+				t.Errorf("Parse(%q) = %+v, want %+v", test.line, got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseBytes(t *testing.T) {
+	type item struct {
+		Count uint8
+		Mark  byte `aoc:",char"`
+		Sym   rune `aoc:"sym,char"`
+	}
+	rx := regexp.MustCompile(`^(?P<count>\S+) (?P<mark>\S+) (?P<sym>\S+)$`)
+
+	tests := []struct {
+		name    string
+		line    string
+		want    item
+		wantErr bool
+	}{
+		{name: "one digit", line: "7 # →", want: item{7, '#', '→'}},
+		{name: "two digits", line: "17 x y", want: item{17, 'x', 'y'}},
+		{name: "too big", line: "300 x y", wantErr: true},
+		{name: "char too long", line: "1 xy z", wantErr: true},
+		{name: "multi-byte char in byte", line: "1 → z", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Parse[item](rx, test.line)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Parse(%q) error = %v, wantErr %v", test.line, err, test.wantErr)
+			}
+			if !test.wantErr && got != test.want {
+				t.Errorf("Parse(%q) = %+v, want %+v", test.line, got, test.want)
+			}
+		})
+	}
+
+	type bad struct {
+		N int `aoc:",char"`
+	}
+	if _, err := Parse[bad](regexp.MustCompile(`(?P<n>\d)`), "1"); err == nil {
+		t.Errorf("Parse() with char option on an int field error = nil, want non-nil")
+	}
+}
+
+func TestParseDuplicateFields(t *testing.T) {
+	rx := regexp.MustCompile(`(?P<a>\d+)`)
+
+	type tagAndName struct {
+		A int
+		B int `aoc:"a"`
+	}
+	if _, err := Parse[tagAndName](rx, "5"); err == nil {
+		t.Errorf("Parse() with a tagged and an untagged field for one group error = nil, want non-nil")
+	}
+
+	type twoTags struct {
+		X int `aoc:"a"`
+		Y int `aoc:"a"`
+	}
+	if _, err := ParseLines[twoTags](rx, []string{"5"}); err == nil {
+		t.Errorf("ParseLines() with two fields tagged for one group error = nil, want non-nil")
+	}
+}
@@ -0,0 +1,234 @@
+package parse
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/glennhartmann/aoclib/grid/d4"
+	"github.com/glennhartmann/aoclib/grid/d8"
+)
+
+var (
+	d4DirType = reflect.TypeFor[d4.Direction]()
+	d8DirType = reflect.TypeFor[d8.Direction]()
+)
+
+// Parse matches |line| against |rx|, and returns a T (which must be a struct)
+// with its fields filled in from rx's named capture groups.
+//
+// A field is filled from the group named in its `aoc:"name"` tag, or if it
+// has no tag (or the tag's name is empty), from the group with the same name
+// as the field (ignoring case). Fields tagged `aoc:"-"` and untagged fields
+// with no matching group are left alone. Values are converted to the field's
+// type automatically: all int, uint and float types, strings, bools and d4/d8
+// Directions (in any notation understood by d8.LookupDir) are supported.
+// Groups that didn't participate in the match leave their field alone.
+//
+// Since byte is the same type as uint8, byte fields are parsed as decimal
+// numbers by default. To fill a byte (or rune) field with a single
+// character instead, add the "char" option to its tag, as in
+// `aoc:",char"` or `aoc:"name,char"`.
+//
+// Parse returns a descriptive error if |line| doesn't match, if a named group
+// or tagged field has nothing to pair up with, if two fields want the same
+// group, or if a value can't be converted.
+func Parse[T any](rx *regexp.Regexp, line string) (T, error) {
+	var ret T
+	sp, err := newStructParser(reflect.TypeFor[T](), rx)
+	if err != nil {
+		return ret, err
+	}
+	err = sp.parse(line, reflect.ValueOf(&ret).Elem())
+	return ret, err
+}
+
+// ParseLines calls Parse on each of |lines|.
+func ParseLines[T any](rx *regexp.Regexp, lines []string) ([]T, error) {
+	sp, err := newStructParser(reflect.TypeFor[T](), rx)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]T, len(lines))
+	for i, line := range lines {
+		if err := sp.parse(line, reflect.ValueOf(&ret[i]).Elem()); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// structParser fills structs of a particular type from the named groups of a
+// particular regexp. Building one does all the reflection on the type, so
+// that it only has to be done once no matter how many lines are parsed.
+type structParser struct {
+	rx *regexp.Regexp
+
+	// fields maps each named group to the index of the field it fills.
+	fields map[string]int
+
+	// chars is the set of field indices tagged with the "char" option.
+	chars map[int]bool
+}
+
+func newStructParser(t reflect.Type, rx *regexp.Regexp) (*structParser, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("Parse: %v is not a struct", t)
+	}
+
+	groups := make(map[string]bool)
+	for _, name := range rx.SubexpNames() {
+		if name != "" {
+			groups[name] = true
+		}
+	}
+
+	sp := &structParser{rx: rx, fields: make(map[string]int), chars: make(map[int]bool)}
+	claim := func(g string, i int) error {
+		if j, ok := sp.fields[g]; ok {
+			return fmt.Errorf("Parse: fields %s and %s of %v both want group %q", t.Field(j).Name, t.Field(i).Name, t, g)
+		}
+		sp.fields[g] = i
+		return nil
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		tag, _ := sf.Tag.Lookup("aoc")
+		if tag == "-" {
+			continue
+		}
+
+		tag, opts, _ := strings.Cut(tag, ",")
+		switch opts {
+		case "":
+		case "char":
+			if k := sf.Type.Kind(); k != reflect.Uint8 && k != reflect.Int32 {
+				return nil, fmt.Errorf("Parse: field %s has the char option, but is a %v rather than a byte or rune", sf.Name, sf.Type)
+			}
+			sp.chars[i] = true
+		default:
+			return nil, fmt.Errorf("Parse: field %s has unknown tag options %q", sf.Name, opts)
+		}
+
+		if tag != "" {
+			if !groups[tag] {
+				return nil, fmt.Errorf("Parse: field %s wants group %q, which isn't in %v", sf.Name, tag, rx)
+			}
+			if err := claim(tag, i); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		var matched string
+		for _, g := range rx.SubexpNames() {
+			if g == "" || g == matched || !strings.EqualFold(g, sf.Name) {
+				continue
+			}
+			if matched != "" {
+				return nil, fmt.Errorf("Parse: field %s matches both group %q and group %q in %v", sf.Name, matched, g, rx)
+			}
+			matched = g
+			if err := claim(g, i); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, g := range rx.SubexpNames() {
+		if _, ok := sp.fields[g]; g != "" && !ok {
+			return nil, fmt.Errorf("Parse: group %q in %v has no matching field in %v", g, rx, t)
+		}
+	}
+
+	return sp, nil
+}
+
+// parse fills |v|, which must be of the structParser's type, from |line|.
+func (sp *structParser) parse(line string, v reflect.Value) error {
+	m := sp.rx.FindStringSubmatchIndex(line)
+	if m == nil {
+		return fmt.Errorf("Parse: %q doesn't match %v", line, sp.rx)
+	}
+
+	for g, name := range sp.rx.SubexpNames() {
+		if name == "" || m[2*g] < 0 {
+			continue
+		}
+		f := sp.fields[name]
+		if err := setField(v.Field(f), line[m[2*g]:m[2*g+1]], v.Type().Field(f).Name, sp.chars[f]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func setField(f reflect.Value, s, name string, char bool) error {
+	if char {
+		r := []rune(s)
+		if len(r) != 1 || (f.Kind() == reflect.Uint8 && len(s) != 1) {
+			return fmt.Errorf("Parse: field %s wants a single character, but got %q", name, s)
+		}
+		if f.Kind() == reflect.Uint8 {
+			f.SetUint(uint64(s[0]))
+		} else {
+			f.SetInt(int64(r[0]))
+		}
+		return nil
+	}
+
+	switch f.Type() {
+	case d4DirType:
+		dir, ok := d4.LookupDir(s)
+		if !ok {
+			return fmt.Errorf("Parse: invalid d4.Direction for field %s: %q", name, s)
+		}
+		f.SetInt(int64(dir))
+		return nil
+	case d8DirType:
+		dir, ok := d8.LookupDir(s)
+		if !ok {
+			return fmt.Errorf("Parse: invalid d8.Direction for field %s: %q", name, s)
+		}
+		f.SetInt(int64(dir))
+		return nil
+	}
+
+	var err error
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		i, err = strconv.ParseInt(s, 10, f.Type().Bits())
+		f.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var u uint64
+		u, err = strconv.ParseUint(s, 10, f.Type().Bits())
+		f.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		var fl float64
+		fl, err = strconv.ParseFloat(s, f.Type().Bits())
+		f.SetFloat(fl)
+	case reflect.Bool:
+		var b bool
+		b, err = strconv.ParseBool(s)
+		f.SetBool(b)
+	default:
+		return fmt.Errorf("Parse: unsupported type %v for field %s", f.Type(), name)
+	}
+
+	if err != nil {
+		return fmt.Errorf("Parse: invalid %v for field %s: %q (%w)", f.Type(), name, s, err)
+	}
+	return nil
+}
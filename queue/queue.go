@@ -6,6 +6,15 @@ import (
 	"github.com/glennhartmann/aoclib/internal/stackqueuebase"
 )
 
+var (
+	// ErrEmpty is returned when popping or peeking from an empty queue.
+	ErrEmpty = stackqueuebase.ErrEmpty
+
+	// ErrOutOfRange is returned when popping or peeking more elements than
+	// the queue holds.
+	ErrOutOfRange = stackqueuebase.ErrOutOfRange
+)
+
 // Queue is a generic queue.
 type Queue[T any] struct {
 	*stackqueuebase.Base[T]
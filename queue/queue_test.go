@@ -1,6 +1,10 @@
 package queue
 
-import "testing"
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
 
 // Note - all the meaningful tests are in internal/stackqueuebase_test
 
@@ -19,7 +23,19 @@ func TestQueue(t *testing.T) {
 	}
 
 	_, err = q.Pop()
-	if err == nil {
-		t.Errorf("q.Pop() = err(nil), want error")
+	if !errors.Is(err, ErrEmpty) {
+		t.Errorf("q.Pop() = err(%v), want ErrEmpty", err)
+	}
+}
+
+func TestQueueString(t *testing.T) {
+	q := NewQueue[int]()
+	q.PushN(1, 2, 3)
+
+	if got, want := q.String(), "front [1, 2, 3] back"; got != want {
+		t.Errorf("q.String() = %q, want %q", got, want)
+	}
+	if got, want := fmt.Sprintf("%v", q), "front [1, 2, 3] back"; got != want {
+		t.Errorf("fmt.Sprintf(\"%%v\", q) = %q, want %q", got, want)
 	}
 }
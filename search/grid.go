@@ -0,0 +1,73 @@
+package search
+
+import (
+	"github.com/glennhartmann/aoclib/common"
+	"github.com/glennhartmann/aoclib/grid"
+	"github.com/glennhartmann/aoclib/grid/d8"
+)
+
+// GridNeighbors4 returns a neighbour function, suitable for BFS and DFS, that
+// moves orthogonally between in-bounds cells of |g| whose values satisfy
+// |passable|.
+func GridNeighbors4[T comparable](g *grid.Grid[T], passable func(T) bool) func(d8.Point) []d8.Point {
+	return gridNeighbors(g, passable, g.Neighbors4)
+}
+
+// GridNeighbors8 is like GridNeighbors4, but also allows diagonal moves.
+func GridNeighbors8[T comparable](g *grid.Grid[T], passable func(T) bool) func(d8.Point) []d8.Point {
+	return gridNeighbors(g, passable, g.Neighbors8)
+}
+
+func gridNeighbors[T comparable](g *grid.Grid[T], passable func(T) bool, all func(d8.Point) []d8.Point) func(d8.Point) []d8.Point {
+	return func(p d8.Point) []d8.Point {
+		var ret []d8.Point
+		for _, n := range all(p) {
+			if passable(g.AtPoint(n)) {
+				ret = append(ret, n)
+			}
+		}
+		return ret
+	}
+}
+
+// GridEdges4 returns an edge function, suitable for Dijkstra and AStar, that
+// moves orthogonally between in-bounds cells of |g|. |cost| returns the cost
+// of entering a cell with the given value, and whether or not it can be
+// entered at all.
+func GridEdges4[T comparable, C common.Real](g *grid.Grid[T], cost func(T) (C, bool)) func(d8.Point) []Edge[d8.Point, C] {
+	return gridEdges(g, cost, g.Neighbors4)
+}
+
+// GridEdges8 is like GridEdges4, but also allows diagonal moves.
+func GridEdges8[T comparable, C common.Real](g *grid.Grid[T], cost func(T) (C, bool)) func(d8.Point) []Edge[d8.Point, C] {
+	return gridEdges(g, cost, g.Neighbors8)
+}
+
+func gridEdges[T comparable, C common.Real](g *grid.Grid[T], cost func(T) (C, bool), all func(d8.Point) []d8.Point) func(d8.Point) []Edge[d8.Point, C] {
+	return func(p d8.Point) []Edge[d8.Point, C] {
+		var ret []Edge[d8.Point, C]
+		for _, n := range all(p) {
+			if c, ok := cost(g.AtPoint(n)); ok {
+				ret = append(ret, Edge[d8.Point, C]{n, c})
+			}
+		}
+		return ret
+	}
+}
+
+// Not returns a passable function that allows every value except |wall|.
+func Not[T comparable](wall T) func(T) bool {
+	return func(v T) bool { return v != wall }
+}
+
+// ManhattanTo returns an AStar heuristic estimating the remaining cost as the
+// Manhattan distance to |target|. It is consistent for orthogonal moves that
+// each cost at least 1.
+func ManhattanTo(target d8.Point) func(d8.Point) int {
+	return func(p d8.Point) int { return p.Manhattan(target) }
+}
+
+// ChebyshevTo is like ManhattanTo, but for 8-directional movement.
+func ChebyshevTo(target d8.Point) func(d8.Point) int {
+	return func(p d8.Point) int { return p.Chebyshev(target) }
+}
@@ -0,0 +1,139 @@
+// Package search implements generic graph search algorithms (BFS, DFS,
+// Dijkstra and A*) over any comparable state type.
+package search
+
+import (
+	"slices"
+
+	"github.com/glennhartmann/aoclib/common"
+	"github.com/glennhartmann/aoclib/queue"
+	"github.com/glennhartmann/aoclib/stack"
+)
+
+// Result holds the outcome of a search.
+type Result[S comparable, C common.Real] struct {
+	// Start is the state the search started from.
+	Start S
+
+	// Dist holds the distance (or cost) from Start to every reached state.
+	Dist map[S]C
+
+	// Prev holds the predecessor of every reached state other than Start.
+	Prev map[S]S
+
+	// Found is whether or not a goal state was reached.
+	Found bool
+
+	// End is the goal state that was reached, if Found is true.
+	End S
+}
+
+func newResult[S comparable, C common.Real](start S) *Result[S, C] {
+	return &Result[S, C]{
+		Start: start,
+		Dist:  map[S]C{start: 0},
+		Prev:  make(map[S]S),
+	}
+}
+
+// Reached returns whether or not |s| was reached by the search.
+func (r *Result[S, C]) Reached(s S) bool {
+	_, ok := r.Dist[s]
+	return ok
+}
+
+// Path returns the sequence of states from Start to |to|, inclusive. If |to|
+// was not reached, nil is returned.
+func (r *Result[S, C]) Path(to S) []S {
+	if !r.Reached(to) {
+		return nil
+	}
+
+	path := []S{to}
+	for s := to; s != r.Start; {
+		s = r.Prev[s]
+		path = append(path, s)
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// EndPath returns the path from Start to End. If no goal was found, nil is
+// returned.
+func (r *Result[S, C]) EndPath() []S {
+	if !r.Found {
+		return nil
+	}
+	return r.Path(r.End)
+}
+
+// BFS performs a breadth-first search starting from |start|. |neighbors|
+// returns the states reachable in one step from a given state. The search
+// stops as soon as a state satisfying |goal| is dequeued; if |goal| is nil,
+// every reachable state is visited. Distances are in number of steps.
+func BFS[S comparable](start S, neighbors func(S) []S, goal func(S) bool) *Result[S, int] {
+	res := newResult[S, int](start)
+
+	q := queue.NewQueue[S]()
+	q.Push(start)
+	for s := range q.Drain() {
+		if goal != nil && goal(s) {
+			res.Found, res.End = true, s
+			return res
+		}
+
+		for _, n := range neighbors(s) {
+			if res.Reached(n) {
+				continue
+			}
+			res.Dist[n] = res.Dist[s] + 1
+			res.Prev[n] = s
+			q.Push(n)
+		}
+	}
+
+	return res
+}
+
+// DFS performs a depth-first search starting from |start|. |neighbors|
+// returns the states reachable in one step from a given state. The search
+// stops as soon as a state satisfying |goal| is visited; if |goal| is nil,
+// every reachable state is visited. Distances are depths in the DFS tree, so
+// unlike BFS they are not necessarily shortest.
+func DFS[S comparable](start S, neighbors func(S) []S, goal func(S) bool) *Result[S, int] {
+	res := newResult[S, int](start)
+	visited := make(map[S]bool)
+
+	st := stack.NewStack[S]()
+	st.Push(start)
+	for s := range st.Drain() {
+		if visited[s] {
+			continue
+		}
+		visited[s] = true
+
+		if goal != nil && goal(s) {
+			res.Found, res.End = true, s
+			return res
+		}
+
+		ns := neighbors(s)
+		// push in reverse so that neighbours are visited in the order given
+		for i := len(ns) - 1; i >= 0; i-- {
+			n := ns[i]
+			if visited[n] {
+				continue
+			}
+			res.Dist[n] = res.Dist[s] + 1
+			res.Prev[n] = s
+			st.Push(n)
+		}
+	}
+
+	return res
+}
+
+// Until returns a goal function that matches only |target|.
+func Until[S comparable](target S) func(S) bool {
+	return func(s S) bool { return s == target }
+}
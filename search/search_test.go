@@ -0,0 +1,112 @@
+package search
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/glennhartmann/aoclib/grid"
+	"github.com/glennhartmann/aoclib/grid/d8"
+)
+
+func setupMaze() (*grid.Grid[byte], d8.Point, d8.Point) {
+	g := grid.FromStrings([]string{
+		"S.#.....",
+		".##.###.",
+		"....#...",
+		"###.#.#.",
+		"....#..E",
+	})
+	return g, g.MustFindPoint('S'), g.MustFindPoint('E')
+}
+
+func TestBFSGrid(t *testing.T) {
+	g, start, end := setupMaze()
+
+	res := BFS(start, GridNeighbors4(g, Not[byte]('#')), Until(end))
+	if !res.Found {
+		t.Fatalf("BFS() didn't find %v", end)
+	}
+	if got := res.Dist[end]; got != 15 {
+		t.Errorf("res.Dist[%v] = %d, want 15", end, got)
+	}
+
+	path := res.EndPath()
+	if len(path) != 16 || path[0] != start || path[len(path)-1] != end {
+		t.Fatalf("res.EndPath() = %v, want 16 points from %v to %v", path, start, end)
+	}
+	for i := 1; i < len(path); i++ {
+		if path[i-1].Manhattan(path[i]) != 1 || g.AtPoint(path[i]) == '#' {
+			t.Errorf("res.EndPath() has invalid step %v -> %v", path[i-1], path[i])
+		}
+	}
+}
+
+func TestBFSUnreachable(t *testing.T) {
+	g := grid.FromStrings([]string{
+		"S#.",
+		"##E",
+	})
+
+	res := BFS(g.MustFindPoint('S'), GridNeighbors4(g, Not[byte]('#')), Until(g.MustFindPoint('E')))
+	if res.Found {
+		t.Errorf("BFS() found unreachable end")
+	}
+	if got := len(res.Dist); got != 1 {
+		t.Errorf("len(res.Dist) = %d, want 1", got)
+	}
+	if got := res.Path(g.MustFindPoint('E')); got != nil {
+		t.Errorf("res.Path(E) = %v, want nil", got)
+	}
+}
+
+func TestDFS(t *testing.T) {
+	g, start, _ := setupMaze()
+
+	res := DFS(start, GridNeighbors4(g, Not[byte]('#')), nil)
+	if got, want := len(res.Dist), 40-g.Count('#'); got != want {
+		t.Errorf("len(res.Dist) = %d, want %d", got, want)
+	}
+}
+
+func TestWeighted(t *testing.T) {
+	g := grid.FromStrings([]string{
+		"11911",
+		"11919",
+		"11111",
+	})
+	cost := func(b byte) (int, bool) { return int(b - '0'), true }
+	start, end := d8.P(0, 0), d8.P(0, 4)
+
+	for _, test := range []struct {
+		name string
+		res  *Result[d8.Point, int]
+	}{
+		{name: "Dijkstra", res: Dijkstra(start, GridEdges4(g, cost), Until(end))},
+		{name: "AStar", res: AStar(start, GridEdges4(g, cost), Until(end), ManhattanTo(end))},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if !test.res.Found {
+				t.Fatalf("didn't find %v", end)
+			}
+			if got := test.res.Dist[end]; got != 8 {
+				t.Errorf("res.Dist[%v] = %d, want 8", end, got)
+			}
+		})
+	}
+}
+
+func TestDijkstraGeneric(t *testing.T) {
+	edges := map[string][]Edge[string, float64]{
+		"a": {{"b", 1.5}, {"c", 4}},
+		"b": {{"c", 1}, {"d", 5}},
+		"c": {{"d", 1}},
+	}
+
+	res := Dijkstra("a", func(s string) []Edge[string, float64] { return edges[s] }, nil)
+	if got := res.Dist["d"]; got != 3.5 {
+		t.Errorf("res.Dist[d] = %v, want 3.5", got)
+	}
+	if got, want := res.Path("d"), []string{"a", "b", "c", "d"}; !slices.Equal(got, want) {
+		t.Errorf("res.Path(d) = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,57 @@
+package search
+
+import (
+	"github.com/glennhartmann/aoclib/common"
+	"github.com/glennhartmann/aoclib/heap"
+)
+
+// Edge is a weighted edge to state To.
+type Edge[S comparable, C common.Real] struct {
+	To   S
+	Cost C
+}
+
+// Dijkstra finds the cheapest paths from |start| using Dijkstra's algorithm.
+// |neighbors| returns the weighted edges out of a given state; costs must be
+// non-negative. The search stops as soon as a state satisfying |goal| is
+// settled; if |goal| is nil, every reachable state is settled.
+func Dijkstra[S comparable, C common.Real](start S, neighbors func(S) []Edge[S, C], goal func(S) bool) *Result[S, C] {
+	return AStar(start, neighbors, goal, func(S) C { return 0 })
+}
+
+// AStar is like Dijkstra, but uses |heuristic| to estimate the remaining cost
+// from a state to the goal, which lets it settle fewer states. The heuristic
+// must be consistent (never overestimating, and obeying the triangle
+// inequality) for the results to be optimal.
+func AStar[S comparable, C common.Real](start S, neighbors func(S) []Edge[S, C], goal func(S) bool, heuristic func(S) C) *Result[S, C] {
+	res := newResult[S, C](start)
+	settled := make(map[S]bool)
+
+	pq := heap.NewPriorityQueue[S, C]()
+	pq.Update(start, heuristic(start))
+	for !pq.Empty() {
+		s, _ := pq.PopMin()
+		settled[s] = true
+
+		if goal != nil && goal(s) {
+			res.Found, res.End = true, s
+			return res
+		}
+
+		d := res.Dist[s]
+		for _, e := range neighbors(s) {
+			if settled[e.To] {
+				continue
+			}
+			nd := d + e.Cost
+			if old, ok := res.Dist[e.To]; ok && old <= nd {
+				continue
+			}
+			res.Dist[e.To] = nd
+			res.Prev[e.To] = s
+			pq.Update(e.To, nd+heuristic(e.To))
+		}
+	}
+
+	return res
+}
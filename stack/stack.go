@@ -6,6 +6,15 @@ import (
 	"github.com/glennhartmann/aoclib/internal/stackqueuebase"
 )
 
+var (
+	// ErrEmpty is returned when popping or peeking from an empty stack.
+	ErrEmpty = stackqueuebase.ErrEmpty
+
+	// ErrOutOfRange is returned when popping or peeking more elements than
+	// the stack holds.
+	ErrOutOfRange = stackqueuebase.ErrOutOfRange
+)
+
 // Stack is a generic queue.
 type Stack[T any] struct {
 	*stackqueuebase.Base[T]
@@ -1,6 +1,10 @@
 package stack
 
-import "testing"
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
 
 // Note - all the meaningful tests are in internal/stackqueuebase_test
 
@@ -19,7 +23,19 @@ func TestStack(t *testing.T) {
 	}
 
 	_, err = q.Pop()
-	if err == nil {
-		t.Errorf("q.Pop() = err(nil), want error")
+	if !errors.Is(err, ErrEmpty) {
+		t.Errorf("q.Pop() = err(%v), want ErrEmpty", err)
+	}
+}
+
+func TestStackString(t *testing.T) {
+	s := NewStack[int]()
+	s.PushN(1, 2, 3)
+
+	if got, want := s.String(), "top [3, 2, 1] bottom"; got != want {
+		t.Errorf("s.String() = %q, want %q", got, want)
+	}
+	if got, want := fmt.Sprintf("%v", s), "top [3, 2, 1] bottom"; got != want {
+		t.Errorf("fmt.Sprintf(\"%%v\", s) = %q, want %q", got, want)
 	}
 }